@@ -2,11 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/joho/godotenv"
 
@@ -21,13 +28,47 @@ import (
 func main() {
 	// Parse command line flags
 	var (
-		configPath  = flag.String("config", "configs/config.yaml", "Path to configuration file")
-		envPath     = flag.String("env", ".env", "Path to environment file")
-		excelPath   = flag.String("excel", "youtube-data.xlsx", "Path to Excel data file")
-		testEmail   = flag.Bool("test-email", false, "Send test email and exit")
-		development = flag.Bool("dev", false, "Run in development mode")
-		showHelp    = flag.Bool("help", false, "Show help message")
+		configPath     = flag.String("config", "configs/config.yaml", "Path to configuration file")
+		configDir      = flag.String("config-dir", "", "Directory of per-profile configuration files")
+		profile        = flag.String("profile", "", "Run only the named profile from -config-dir")
+		envPath        = flag.String("env", ".env", "Path to environment file")
+		excelPath      = flag.String("excel", "youtube-data.xlsx", "Path to Excel data file")
+		storageType    = flag.String("storage", "excel", "Storage backend: excel or memory")
+		testEmail      = flag.Bool("test-email", false, "Send test email and exit")
+		exportNotion   = flag.Bool("export-notion", false, "Export stored summaries to the Notion database and exit")
+		weeklyRoundup  = flag.Bool("weekly-roundup", false, "Email a roundup of the past 7 days' summaries and exit")
+		pruneProcessed = flag.Bool("prune-processed", false, "Forget processed videos (all, or -channel) so they are summarized again, and exit")
+		resummarize    = flag.String("resummarize-model-before", "", "Regenerate summaries written by a model other than this one, or created before this date (YYYY-MM-DD), and exit")
+		repair         = flag.Bool("repair", false, "Rebuild a corrupted Excel file from its readable rows and backups, then exit")
+		compact        = flag.Bool("compact", false, "Rewrite the Excel file without blank rows and gaps, then exit")
+		testTranscript = flag.String("test-transcript", "", "Fetch and print the transcript for this video ID, then exit")
+		previewChannel = flag.String("preview-channel", "", "List the recent videos of this channel ID or @handle without processing them, then exit")
+		sinceLastRun   = flag.Bool("since-last-run", false, "Only consider videos published since the last successful run")
+		resume         = flag.Bool("resume", false, "Skip the channels an interrupted run already finished")
+		runTimeout     = flag.Duration("timeout", 0, "Stop the run after this long, e.g. 50m (overrides processing.run_timeout)")
+		runLog         = flag.String("run-log", "", "Append a JSON summary of each run to this file (one object per line)")
+		serveAddr      = flag.String("serve", "", "Serve the HTTP UI endpoints on this address (e.g. :8080)")
+		listSummaries  = flag.Bool("list-summaries", false, "List stored summaries and exit")
+		listFailures   = flag.Bool("list-failures", false, "List videos that failed every retry and haven't been summarized since, then exit")
+		showStats      = flag.Bool("stats", false, "Print totals over all stored summaries (per channel, recent, estimated tokens and cost), then exit")
+		statusFilter   = flag.String("status", "", "With -list-summaries: only show this status (New, Processed, Skipped, Removed)")
+		channelFilter  = flag.String("channel", "", "With -list-summaries: only show channels whose name contains this text; with -prune-processed: the channel ID or name to reset")
+		sinceFilter    = flag.String("since", "", "With -list-summaries: only show summaries newer than this age (e.g. 7d, 12h)")
+		initFiles      = flag.Bool("init", false, "Create a commented config file, .env template and data file, then exit")
+		force          = flag.Bool("force", false, "With -init: overwrite existing config and .env files")
+		development    = flag.Bool("dev", false, "Run in development mode")
+		showHelp       = flag.Bool("help", false, "Show help message")
 	)
+
+	// Channel management subcommands take their own flags
+	if len(os.Args) > 1 && (os.Args[1] == "add-channel" || os.Args[1] == "list-channels") {
+		if err := runChannelCommand(os.Args[1], os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	flag.Parse()
 
 	if *showHelp {
@@ -44,11 +85,66 @@ func main() {
 
 	appLogger.Info("Starting YouTube Summarizer", "version", "1.0.0", "development", *development)
 
+	// Scaffold config, env and data files for new users
+	if *initFiles {
+		if err := initProject(*configPath, *envPath, *excelPath, *force, appLogger); err != nil {
+			appLogger.Error("Initialization failed", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Load environment variables
 	if err := godotenv.Load(*envPath); err != nil {
 		appLogger.Warn("Failed to load .env file (continuing with environment variables)", "error", err)
 	}
 
+	var since time.Time
+	if *sinceFilter != "" {
+		age, err := parseAge(*sinceFilter)
+		if err != nil {
+			appLogger.Error("Invalid -since value", err)
+			os.Exit(1)
+		}
+		since = time.Now().Add(-age)
+	}
+
+	opts := runOptions{
+		storageType:    *storageType,
+		excelPath:      *excelPath,
+		testEmail:      *testEmail,
+		exportNotion:   *exportNotion,
+		weeklyRoundup:  *weeklyRoundup,
+		pruneProcessed: *pruneProcessed,
+		repair:         *repair,
+		compact:        *compact,
+		resummarize:    *resummarize,
+		testTranscript: *testTranscript,
+		previewChannel: *previewChannel,
+		runLog:         *runLog,
+		sinceLastRun:   *sinceLastRun,
+		resume:         *resume,
+		runTimeout:     *runTimeout,
+		serveAddr:      *serveAddr,
+		listSummaries:  *listSummaries,
+		listFailures:   *listFailures,
+		showStats:      *showStats,
+		summaryFilter: types.SummaryFilter{
+			Status:  *statusFilter,
+			Channel: *channelFilter,
+			Since:   since,
+		},
+	}
+
+	// Run each profile from the config directory when one is given
+	if *configDir != "" {
+		if err := runProfiles(*configDir, *profile, *envPath, opts, appLogger); err != nil {
+			appLogger.Error("Profile run failed", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Load configuration
 	configLoader := config.NewLoader(*configPath, *envPath)
 	cfg, err := configLoader.Load()
@@ -56,56 +152,381 @@ func main() {
 		appLogger.Error("Failed to load configuration", err)
 		os.Exit(1)
 	}
+	warnMissingSections(configLoader, appLogger)
 
 	appLogger.Info("Configuration loaded successfully")
 
-	// Initialize application
-	app, err := initializeApp(cfg, *excelPath, appLogger)
-	if err != nil {
-		appLogger.Error("Failed to initialize application", err)
+	if err := runWithConfig(cfg, opts, appLogger); err != nil {
+		appLogger.Error("Application error", err)
 		os.Exit(1)
 	}
+}
+
+// runOptions holds the command line choices that apply to each run
+type runOptions struct {
+	storageType    string
+	excelPath      string
+	testEmail      bool
+	exportNotion   bool
+	weeklyRoundup  bool
+	pruneProcessed bool
+	repair         bool
+	compact        bool
+	resummarize    string
+	testTranscript string
+	previewChannel string
+	runLog         string
+	sinceLastRun   bool
+	resume         bool
+	runTimeout     time.Duration
+	serveAddr      string
+
+	listSummaries bool
+	summaryFilter types.SummaryFilter
+	listFailures  bool
+	showStats     bool
+}
+
+// runWithConfig initializes the application for one configuration and runs it
+// (or runs the requested one-off command)
+func runWithConfig(cfg *types.Config, opts runOptions, appLogger *logger.Logger) error {
+	// Serve mode only needs local files
+	if opts.serveAddr != "" {
+		return serveUI(cfg, opts.serveAddr, appLogger)
+	}
+
+	// Transcript debugging only needs the transcript client
+	if opts.testTranscript != "" {
+		return printTranscript(context.Background(), cfg, opts.testTranscript, appLogger)
+	}
+
+	// Channel previews only need the YouTube client
+	if opts.previewChannel != "" {
+		return printChannelPreview(context.Background(), cfg, opts.previewChannel, appLogger)
+	}
+
+	// Repair works on the raw file, before storage would try to open it
+	if opts.repair {
+		return repairExcelFile(cfg, opts.storageType, opts.excelPath, appLogger)
+	}
+
+	// Compacting only needs storage
+	if opts.compact {
+		dataStorage, err := initializeStorage(cfg, opts.storageType, opts.excelPath, true, appLogger)
+		if err != nil {
+			return err
+		}
+		return compactStorage(context.Background(), dataStorage, opts.storageType, opts.excelPath)
+	}
+
+	// Listing summaries only needs storage
+	if opts.listSummaries {
+		dataStorage, err := initializeStorage(cfg, opts.storageType, opts.excelPath, false, appLogger)
+		if err != nil {
+			return err
+		}
+		return listStoredSummaries(context.Background(), dataStorage, opts.summaryFilter)
+	}
+
+	// Listing failures only needs storage
+	if opts.listFailures {
+		dataStorage, err := initializeStorage(cfg, opts.storageType, opts.excelPath, false, appLogger)
+		if err != nil {
+			return err
+		}
+		return listVideoFailures(context.Background(), dataStorage)
+	}
+
+	// Stats only need storage
+	if opts.showStats {
+		dataStorage, err := initializeStorage(cfg, opts.storageType, opts.excelPath, false, appLogger)
+		if err != nil {
+			return err
+		}
+		return printSummaryStats(context.Background(), dataStorage)
+	}
+
+	// Resetting dedup state only needs storage
+	if opts.pruneProcessed {
+		dataStorage, err := initializeStorage(cfg, opts.storageType, opts.excelPath, true, appLogger)
+		if err != nil {
+			return err
+		}
+		return pruneProcessedVideos(context.Background(), dataStorage, opts.summaryFilter.Channel, appLogger)
+	}
+
+	// Notion export only needs storage
+	if opts.exportNotion {
+		dataStorage, err := initializeStorage(cfg, opts.storageType, opts.excelPath, false, appLogger)
+		if err != nil {
+			return err
+		}
+		return exportToNotion(context.Background(), dataStorage, cfg, appLogger)
+	}
+
+	// Initialize application; test emails and the weekly roundup only read storage
+	backup := !opts.testEmail && !opts.weeklyRoundup
+	app, err := initializeApp(cfg, opts.storageType, opts.excelPath, backup, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
 
 	// Handle test email mode
-	if *testEmail {
+	if opts.testEmail {
 		appLogger.Info("Running in test email mode")
 		if err := app.emailService.SendTestEmail(context.Background()); err != nil {
-			appLogger.Error("Failed to send test email", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to send test email: %w", err)
 		}
 		appLogger.Info("Test email sent successfully")
-		return
+		return nil
+	}
+
+	// Regenerating summaries needs the AI, YouTube and transcript clients but sends nothing
+	if opts.resummarize != "" {
+		cutoff, err := services.ParseResummarizeCutoff(opts.resummarize)
+		if err != nil {
+			return err
+		}
+		updated, err := app.processor.ResummarizeOlder(context.Background(), cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to regenerate summaries: %w", err)
+		}
+		fmt.Printf("Regenerated %d summaries\n", updated)
+		return nil
+	}
+
+	// Weekly roundup reads the past week's summaries without changing their status
+	if opts.weeklyRoundup {
+		return sendWeeklyRoundup(context.Background(), app)
 	}
 
 	// Run the application
-	if err := runApp(app, appLogger); err != nil {
-		appLogger.Error("Application error", err)
-		os.Exit(1)
+	// Bound the whole run so a stalled provider can't keep it alive into the next cron run
+	ctx := context.Background()
+	timeout := cfg.Processing.RunTimeout
+	if opts.runTimeout > 0 {
+		timeout = opts.runTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("run exceeded its %s timeout", timeout))
+		defer cancel()
+	}
+
+	if opts.sinceLastRun {
+		lastRun, err := app.storage.GetLastSuccessfulRun(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get last successful run: %w", err)
+		}
+		if lastRun.IsZero() {
+			appLogger.Info("No successful run recorded yet, considering all recent videos")
+		} else {
+			appLogger.Info("Only considering videos published since the last successful run", "since", lastRun.Format("2006-01-02 15:04:05"))
+			app.processor.SetPublishedAfter(lastRun)
+		}
+	}
+
+	// Checkpoint finished channels next to the data file so -resume can skip them
+	if opts.storageType == "excel" {
+		app.processor.SetCheckpoint(checkpointPath(opts.excelPath), opts.resume)
+	} else if opts.resume {
+		appLogger.Warn("Ignoring -resume since in-memory storage keeps no checkpoint")
+	}
+
+	return runApp(ctx, app, opts.runLog, appLogger)
+}
+
+// checkpointPath returns the run checkpoint file kept next to an Excel data file,
+// e.g. youtube-data.checkpoint.json
+func checkpointPath(excelPath string) string {
+	return strings.TrimSuffix(excelPath, filepath.Ext(excelPath)) + ".checkpoint.json"
+}
+
+// transcriptPreviewChars is how much of the start and end of a transcript -test-transcript prints
+const transcriptPreviewChars = 200
+
+// printTranscript fetches one video's transcript and prints what the summarizer
+// would receive, to tell transcript problems apart from summarization problems
+func printTranscript(ctx context.Context, cfg *types.Config, videoID string, appLogger *logger.Logger) error {
+	transcriptClient := initializeTranscriptClient(cfg, os.Getenv("RAPID_API_KEY"), appLogger)
+	data, err := transcriptClient.GetTranscriptWithThumbnail(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch transcript for %s: %w", videoID, err)
+	}
+
+	runes := []rune(data.Transcript)
+	first, last := runes, runes
+	if len(runes) > transcriptPreviewChars {
+		first = runes[:transcriptPreviewChars]
+		last = runes[len(runes)-transcriptPreviewChars:]
+	}
+
+	fmt.Printf("Video:      %s\n", videoID)
+	fmt.Printf("Length:     %d characters, %d words\n", len(data.Transcript), len(strings.Fields(data.Transcript)))
+	fmt.Printf("Segments:   %d\n", data.SegmentCount)
+	fmt.Printf("Thumbnail:  %s\n", data.ThumbnailURL)
+	fmt.Printf("\nFirst %d chars:\n%s\n", len(first), string(first))
+	fmt.Printf("\nLast %d chars:\n%s\n", len(last), string(last))
+	return nil
+}
+
+// channelPreviewCount is how many recent videos -preview-channel lists
+const channelPreviewCount = 10
+
+// printChannelPreview lists a channel's recent videos so it can be checked before
+// subscribing; nothing is summarized or stored
+func printChannelPreview(ctx context.Context, cfg *types.Config, idOrHandle string, appLogger *logger.Logger) error {
+	youtubeAPIKey := os.Getenv("YOUTUBE_API_KEY")
+	if youtubeAPIKey == "" {
+		return fmt.Errorf("YOUTUBE_API_KEY environment variable is required")
+	}
+	youtubeClient := clients.NewYouTubeClient(youtubeAPIKey, clients.NewHTTPClient(cfg.Timeouts.YouTube, cfg.HTTP.Proxy, clients.RetryPolicyFromConfig(cfg.HTTP)), appLogger)
+
+	channelID, err := youtubeClient.ResolveChannelID(ctx, idOrHandle)
+	if err != nil {
+		return fmt.Errorf("failed to resolve channel %s: %w", idOrHandle, err)
+	}
+
+	videos, err := youtubeClient.GetChannelVideos(ctx, channelID, channelPreviewCount, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to get videos for channel %s: %w", channelID, err)
+	}
+
+	channelName := ""
+	if len(videos) > 0 {
+		channelName = videos[0].ChannelName
+	}
+	fmt.Printf("Channel: %s (%s)\n\n", channelName, channelID)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PUBLISHED\tDURATION\tTITLE\tURL")
+	for _, video := range videos {
+		duration := types.HumanizeDuration(video.Duration)
+		if duration == "" {
+			duration = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			video.PublishedAt.Local().Format("2006-01-02 15:04"),
+			duration,
+			video.Title,
+			video.URL)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d videos\n", len(videos))
+	return nil
+}
+
+// warnMissingSections flags config sections that silently fell back to defaults,
+// e.g. a missing email block quietly sending through smtp.gmail.com
+func warnMissingSections(loader *config.Loader, appLogger *logger.Logger) {
+	missing := loader.MissingSections()
+	if len(missing) == 0 {
+		return
+	}
+	appLogger.Warn("Config sections missing from config file, using built-in defaults for them",
+		"configPath", loader.ConfigPath(),
+		"sections", strings.Join(missing, ", "))
+}
+
+// initProject writes a commented config, an .env template and an initialized data file.
+// Nothing is written if the config or .env file exists, unless force is set; an
+// existing data file is never wiped, only given any missing sheets.
+func initProject(configPath, envPath, excelPath string, force bool, appLogger *logger.Logger) error {
+	if !force {
+		for _, path := range []string{configPath, envPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists (use -force to overwrite)", path)
+			}
+		}
+	}
+
+	if err := config.WriteDefaultConfig(configPath, force); err != nil {
+		return err
+	}
+	appLogger.Info("Wrote config file", "path", configPath)
+
+	if err := config.WriteEnvTemplate(envPath, force); err != nil {
+		return err
+	}
+	appLogger.Info("Wrote environment template", "path", envPath)
+
+	if err := storage.NewExcelStorage(excelPath, appLogger).Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize data file: %w", err)
+	}
+	appLogger.Info("Initialized data file", "path", excelPath)
+
+	fmt.Printf("\nNext steps:\n  1. Add your API keys to %s\n  2. Add channels to the Channels sheet in %s\n  3. Run %s\n",
+		envPath, excelPath, filepath.Base(os.Args[0]))
+	return nil
+}
+
+// runProfiles runs every profile in configDir, or only the named profile.
+// A failing profile doesn't stop the others; an error is returned if any failed.
+func runProfiles(configDir, profile, envPath string, opts runOptions, appLogger *logger.Logger) error {
+	profiles := []string{profile}
+	if profile == "" {
+		var err error
+		profiles, err = config.ListProfiles(configDir)
+		if err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			return fmt.Errorf("no profile configs found in %s", configDir)
+		}
+	}
+
+	var failed []string
+	for _, name := range profiles {
+		appLogger.Info("Running profile", "profile", name)
+
+		profileLoader := config.NewProfileLoader(configDir, name, envPath)
+		cfg, err := profileLoader.Load()
+		if err != nil {
+			appLogger.Error("Failed to load profile configuration", err, "profile", name)
+			failed = append(failed, name)
+			continue
+		}
+		warnMissingSections(profileLoader, appLogger)
+
+		// Each profile keeps its own data file
+		profileOpts := opts
+		profileOpts.excelPath = cfg.Storage.ExcelPath
+		if profileOpts.excelPath == "" {
+			profileOpts.excelPath = fmt.Sprintf("youtube-data-%s.xlsx", name)
+		}
+
+		if err := runWithConfig(cfg, profileOpts, appLogger); err != nil {
+			appLogger.Error("Profile run failed", err, "profile", name)
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d profiles failed: %s", len(failed), len(profiles), strings.Join(failed, ", "))
 	}
+	return nil
 }
 
 // App holds all application dependencies
 type App struct {
-	storage      *storage.ExcelStorage
+	storage      types.Storage
 	processor    *services.VideoProcessor
 	emailService *services.EmailService
 	config       *types.Config
 	logger       types.Logger
 }
 
-// initializeApp sets up all dependencies and services
-func initializeApp(cfg *types.Config, excelPath string, appLogger *logger.Logger) (*App, error) {
+// initializeApp sets up all dependencies and services, backing up storage first when backup is set
+func initializeApp(cfg *types.Config, storageType, excelPath string, backup bool, appLogger *logger.Logger) (*App, error) {
 	// Get required environment variables
 	youtubeAPIKey := os.Getenv("YOUTUBE_API_KEY")
 	if youtubeAPIKey == "" {
 		return nil, fmt.Errorf("YOUTUBE_API_KEY environment variable is required")
 	}
 
-	claudeAPIKey := os.Getenv("CLAUDE_API_KEY")
-	if claudeAPIKey == "" {
-		return nil, fmt.Errorf("CLAUDE_API_KEY environment variable is required")
-	}
-
 	rapidAPIKey := os.Getenv("RAPID_API_KEY")
 	if rapidAPIKey == "" {
 		appLogger.Warn("RAPID_API_KEY not found, transcript functionality may be limited")
@@ -113,52 +534,61 @@ func initializeApp(cfg *types.Config, excelPath string, appLogger *logger.Logger
 
 	emailUsername := os.Getenv("EMAIL_USERNAME")
 	emailPassword := os.Getenv("EMAIL_PASSWORD")
-	if emailUsername == "" || emailPassword == "" {
+	// An unauthenticated relay (email.auth none) needs no credentials
+	emailEnabled := cfg.Email.Auth == "none" || (emailUsername != "" && emailPassword != "")
+	if !emailEnabled {
 		appLogger.Warn("Email credentials not found, email functionality will be disabled")
 	}
 
 	// Initialize storage
-	excelStorage := storage.NewExcelStorage(excelPath, appLogger)
-	if err := excelStorage.Initialize(); err != nil {
-		return nil, fmt.Errorf("failed to initialize Excel storage: %w", err)
+	dataStorage, err := initializeStorage(cfg, storageType, excelPath, backup, appLogger)
+	if err != nil {
+		return nil, err
 	}
 
-	// Initialize API clients
-	youtubeClient := clients.NewYouTubeClient(youtubeAPIKey, appLogger)
-	claudeClient := clients.NewClaudeClient(claudeAPIKey, appLogger)
-
-	var transcriptClient types.TranscriptClient
-	if rapidAPIKey != "" {
-		transcriptClient = clients.NewTranscriptClient(rapidAPIKey, appLogger)
-	} else {
-		// Use mock transcript client if no API key
-		transcriptClient = clients.NewMockTranscriptClient(appLogger)
-		appLogger.Info("Using mock transcript client (no RapidAPI key provided)")
+	// Initialize API clients with their configured request timeouts
+	youtubeClient := clients.NewYouTubeClient(youtubeAPIKey, clients.NewHTTPClient(cfg.Timeouts.YouTube, cfg.HTTP.Proxy, clients.RetryPolicyFromConfig(cfg.HTTP)), appLogger)
+	aiClient, err := initializeAIClient(cfg, appLogger)
+	if err != nil {
+		return nil, err
 	}
 
+	transcriptClient := initializeTranscriptClient(cfg, rapidAPIKey, appLogger)
+
 	// Initialize services
 	processor := services.NewVideoProcessor(
-		excelStorage,
+		dataStorage,
 		youtubeClient,
 		transcriptClient,
-		claudeClient,
+		aiClient,
 		cfg,
 		appLogger,
 	)
 
+	if cfg.Storage.ThumbnailDir != "" {
+		processor.SetThumbnailCache(clients.NewThumbnailCache(cfg.Storage.ThumbnailDir, clients.NewHTTPClient(cfg.Timeouts.YouTube, cfg.HTTP.Proxy, clients.RetryPolicyFromConfig(cfg.HTTP)), appLogger))
+	}
+	if cfg.Storage.SummaryCacheDir != "" {
+		processor.SetSummaryCache(storage.NewFileSummaryCache(cfg.Storage.SummaryCacheDir, appLogger))
+	}
+	if cfg.Storage.SaveTranscripts {
+		processor.SetTranscriptStore(storage.NewFileTranscriptStore(cfg.Storage.TranscriptDir, appLogger))
+	}
+
 	var emailService *services.EmailService
-	if emailUsername != "" && emailPassword != "" {
+	if emailEnabled {
 		var err error
 		emailService, err = services.NewEmailService(cfg, emailUsername, emailPassword, appLogger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize email service: %w", err)
 		}
+		emailService.SetAIClient(aiClient)
 	} else {
 		appLogger.Warn("Email service disabled due to missing credentials")
 	}
 
 	return &App{
-		storage:      excelStorage,
+		storage:      dataStorage,
 		processor:    processor,
 		emailService: emailService,
 		config:       cfg,
@@ -166,37 +596,186 @@ func initializeApp(cfg *types.Config, excelPath string, appLogger *logger.Logger
 	}, nil
 }
 
-// runApp runs the application once and exits (on-demand processing)
-func runApp(app *App, appLogger *logger.Logger) error {
-	// Create context for processing
-	ctx := context.Background()
+// initializeAIClient builds the ai.providers fallback chain, requiring an API key for each provider.
+// AI requests skip http.retry_attempts: Claude retries 429s itself, a failing provider
+// falls back to the next one, and processing.video_retries retries the whole video.
+func initializeAIClient(cfg *types.Config, appLogger *logger.Logger) (*clients.FallbackAIClient, error) {
+	var providers []types.NamedAIClient
+	for _, name := range cfg.AI.Providers {
+		switch name {
+		case "claude":
+			apiKey := os.Getenv("CLAUDE_API_KEY")
+			if apiKey == "" {
+				return nil, fmt.Errorf("CLAUDE_API_KEY environment variable is required")
+			}
+			// 429s are retried by the client itself, which honours the rate-limit headers
+			claudeClient := clients.NewClaudeClient(apiKey, clients.NewHTTPClient(cfg.Timeouts.AI, cfg.HTTP.Proxy, clients.NoRetries), appLogger)
+			claudeClient.SetThrottle(cfg.AI.ThrottleMinRequests, cfg.AI.ThrottleMinTokens)
+			claudeClient.SetPromptTemplate(cfg.AI.SummaryPrompt)
+			claudeClient.SetSampling(cfg.AI.MaxTokens, cfg.AI.Temperature)
+			claudeClient.SetRateLimitRetries(cfg.AI.RateLimitRetries)
+			if cfg.AI.LogRequests {
+				claudeClient.SetRequestLogger(appLogger.Verbose())
+			}
+			providers = append(providers, claudeClient)
+		case "openai":
+			apiKey := os.Getenv("OPENAI_API_KEY")
+			if apiKey == "" {
+				return nil, fmt.Errorf("OPENAI_API_KEY environment variable is required when ai.providers includes openai")
+			}
+			openAIClient := clients.NewOpenAIClient(apiKey, clients.NewHTTPClient(cfg.Timeouts.AI, cfg.HTTP.Proxy, clients.NoRetries), appLogger)
+			openAIClient.SetPromptTemplate(cfg.AI.SummaryPrompt)
+			if cfg.AI.LogRequests {
+				openAIClient.SetRequestLogger(appLogger.Verbose())
+			}
+			providers = append(providers, openAIClient)
+		default:
+			return nil, fmt.Errorf("unsupported AI provider: %s", name)
+		}
+	}
+
+	return clients.NewFallbackAIClient(appLogger, providers...), nil
+}
 
+// initializeTranscriptClient creates the RapidAPI transcript client, or without a
+// RapidAPI key, one that reads the public captions from YouTube
+func initializeTranscriptClient(cfg *types.Config, rapidAPIKey string, appLogger *logger.Logger) types.TranscriptClient {
+	httpClient := clients.NewHTTPClient(cfg.Timeouts.Transcript, cfg.HTTP.Proxy, clients.RetryPolicyFromConfig(cfg.HTTP))
+	if rapidAPIKey != "" {
+		rapidClient := clients.NewTranscriptClient(rapidAPIKey, cfg.Transcript.BaseURL, cfg.Transcript.Host, httpClient, appLogger)
+		rapidClient.SetLanguages(cfg.Transcript.Languages)
+		return rapidClient
+	}
+
+	captionsClient := clients.NewAlternativeTranscriptClient(httpClient, appLogger)
+	captionsClient.SetLanguages(cfg.Transcript.Languages)
+	appLogger.Info("Using YouTube captions for transcripts (no RapidAPI key provided)")
+	return captionsClient
+}
+
+// initializeStorage creates the selected storage backend. With backup set, an existing
+// Excel file is snapshotted first; read-only commands skip that so they don't rotate
+// out the backups taken before writes.
+func initializeStorage(cfg *types.Config, storageType, excelPath string, backup bool, appLogger *logger.Logger) (types.Storage, error) {
+	switch storageType {
+	case "memory":
+		appLogger.Info("Using in-memory storage (nothing will be persisted)")
+		return storage.NewMemoryStorage(), nil
+	case "excel":
+		excelStorage := storage.NewExcelStorage(excelPath, appLogger)
+		excelStorage.SetSaveRetry(cfg.Storage.SaveRetries+1, cfg.Storage.SaveRetryDelay)
+		if cfg.Storage.Mode == "latest" {
+			excelStorage.SetLatestCount(cfg.Storage.LatestCount)
+		}
+		// Snapshot the existing file before touching it
+		if backup {
+			if err := excelStorage.Backup(cfg.Storage.BackupsToKeep); err != nil {
+				return nil, fmt.Errorf("failed to back up Excel storage: %w", err)
+			}
+		}
+		if err := excelStorage.Initialize(); err != nil {
+			return nil, fmt.Errorf("failed to initialize Excel storage: %w", err)
+		}
+		return excelStorage, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (expected excel or memory)", storageType)
+	}
+}
+
+// runApp runs the application once and exits (on-demand processing).
+// When runLogPath is set, a RunReport for the run is appended to it.
+func runApp(ctx context.Context, app *App, runLogPath string, appLogger *logger.Logger) (err error) {
 	appLogger.Info("Starting on-demand video processing")
 
+	// Log API usage even when the run fails, e.g. to diagnose quota exhaustion
+	defer func() {
+		if calls := app.processor.APICalls(); len(calls) > 0 {
+			appLogger.Info("API usage this run", "calls", services.FormatAPICalls(calls))
+		}
+	}()
+
+	emailSent := false
+	if runLogPath != "" {
+		defer func() {
+			report := app.processor.RunReport()
+			report.FinishedAt = time.Now()
+			report.EmailSent = emailSent
+			if err != nil {
+				report.Error = err.Error()
+			}
+			if logErr := services.AppendRunLog(runLogPath, report); logErr != nil {
+				appLogger.Error("Failed to write run log", logErr, "path", runLogPath)
+			}
+		}()
+	}
+
 	// Process all new videos from configured channels
 	if err := app.processor.ProcessNewVideos(ctx); err != nil {
 		appLogger.Error("Failed to process videos", err)
 		return err
 	}
 
-	// Send email digest if there are pending summaries and email is configured
-	if app.emailService != nil {
+	// Past the run timeout, leave the digest for the next run rather than risk a partial send
+	if ctx.Err() != nil {
+		return fmt.Errorf("stopped before sending the digest; summaries finished so far are saved for the next run: %w", context.Cause(ctx))
+	}
+
+	// Record the run for -since-last-run, unless a video or channel failed and must be retried
+	if err := app.processor.RecordSuccessfulRun(ctx); err != nil {
+		appLogger.Error("Failed to record successful run", err)
+	}
+
+	// Send email digest if there are pending summaries, email is configured,
+	// and we're inside the send window (otherwise summaries stay pending)
+	inWindow, err := config.InSendWindow(app.config.Email.SendWindow, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to evaluate email send window: %w", err)
+	}
+	digestDue, nextDigest, err := digestIntervalElapsed(ctx, app, time.Now())
+	if err != nil {
+		return err
+	}
+	forced, oldest, err := pendingOverdue(ctx, app, time.Now())
+	if err != nil {
+		return err
+	}
+	if forced {
+		appLogger.Warn("Forcing digest past the send window, interval and minimum since a pending summary is older than email.force_send_after",
+			"forceSendAfter", app.config.Email.ForceSendAfter, "oldestPending", oldest.Format("2006-01-02 15:04"))
+	}
+	if app.emailService != nil && !inWindow && !forced {
+		appLogger.Info("Outside email send window, leaving summaries pending", "sendWindow", app.config.Email.SendWindow)
+	} else if app.emailService != nil && !digestDue && !forced {
+		appLogger.Info("Last digest was sent too recently, leaving summaries pending",
+			"minDigestInterval", app.config.Email.MinDigestInterval, "nextDigestAfter", nextDigest.Format("2006-01-02 15:04"))
+	} else if app.emailService != nil {
 		summaries, err := app.processor.ProcessPendingSummariesForEmail(ctx)
 		if err != nil {
 			appLogger.Error("Failed to get summaries for email", err)
+		} else if len(summaries) > 0 && len(summaries) < app.config.Email.MinSummaries && !forced {
+			appLogger.Info("Too few summaries for a digest, leaving them pending",
+				"summaryCount", len(summaries), "minSummaries", app.config.Email.MinSummaries)
 		} else if len(summaries) > 0 {
 			appLogger.Info("Sending email digest", "summaryCount", len(summaries))
+			sent := summaries
 			if err := app.emailService.SendDigest(ctx, summaries); err != nil {
-				appLogger.Error("Failed to send email digest", err)
-			} else {
+				// Digests that did go out are still marked processed; the rest stay pending
+				sent = services.SentSummaries(err)
+				appLogger.Error("Failed to send email digest", err, "sentCount", len(sent), "pendingCount", len(summaries)-len(sent))
+			}
+			if len(sent) > 0 {
+				emailSent = true
+				if err := app.storage.SetLastDigestSent(ctx, time.Now()); err != nil {
+					appLogger.Error("Failed to record digest send time", err)
+				}
 				// Mark summaries as processed
-				summaryIDs := make([]string, len(summaries))
-				for i, summary := range summaries {
+				summaryIDs := make([]string, len(sent))
+				for i, summary := range sent {
 					summaryIDs[i] = summary.ID
 				}
 				if err := app.storage.MarkSummariesProcessed(ctx, summaryIDs); err != nil {
 					appLogger.Error("Failed to mark summaries as processed", err)
-				} else {
+				} else if len(sent) == len(summaries) {
 					appLogger.Info("Email digest sent successfully")
 				}
 			}
@@ -209,6 +788,495 @@ func runApp(app *App, appLogger *logger.Logger) error {
 	return nil
 }
 
+// digestIntervalElapsed reports whether email.min_digest_interval has passed since the
+// last digest, and if not, when it will have
+func digestIntervalElapsed(ctx context.Context, app *App, now time.Time) (bool, time.Time, error) {
+	interval := app.config.Email.MinDigestInterval
+	if interval <= 0 || app.emailService == nil {
+		return true, time.Time{}, nil
+	}
+
+	lastSent, err := app.storage.GetLastDigestSent(ctx)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to get last digest time: %w", err)
+	}
+	next := lastSent.Add(interval)
+	return lastSent.IsZero() || !now.Before(next), next, nil
+}
+
+// pendingOverdue reports whether a pending summary is older than email.force_send_after,
+// which forces a digest past the send gates, along with when the oldest one was made
+func pendingOverdue(ctx context.Context, app *App, now time.Time) (bool, time.Time, error) {
+	maxAge := app.config.Email.ForceSendAfter
+	if maxAge <= 0 || app.emailService == nil {
+		return false, time.Time{}, nil
+	}
+
+	pending, err := app.storage.GetPendingSummaries(ctx)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to get pending summaries: %w", err)
+	}
+	var oldest time.Time
+	for _, summary := range pending {
+		if oldest.IsZero() || summary.CreatedAt.Before(oldest) {
+			oldest = summary.CreatedAt
+		}
+	}
+	return !oldest.IsZero() && now.Sub(oldest) >= maxAge, oldest, nil
+}
+
+// exportToNotion creates a Notion page for each stored summary not already in the database
+func exportToNotion(ctx context.Context, dataStorage types.Storage, cfg *types.Config, appLogger *logger.Logger) error {
+	notionToken := os.Getenv("NOTION_API_KEY")
+	if notionToken == "" {
+		return fmt.Errorf("NOTION_API_KEY environment variable is required for Notion export")
+	}
+	if cfg.Notion.DatabaseID == "" {
+		return fmt.Errorf("notion.database_id must be set for Notion export")
+	}
+
+	notionClient := clients.NewNotionClient(notionToken, cfg.Notion.DatabaseID, clients.NewHTTPClient(cfg.Timeouts.Notion, cfg.HTTP.Proxy, clients.RetryPolicyFromConfig(cfg.HTTP)), appLogger)
+
+	summaries, _, err := dataStorage.GetAllSummaries(ctx, 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get summaries for Notion export: %w", err)
+	}
+
+	exported, skipped := 0, 0
+	for _, summary := range summaries {
+		if summary.Status == "Skipped" {
+			continue
+		}
+
+		exists, err := notionClient.PageExists(ctx, summary.VideoURL)
+		if err != nil {
+			return fmt.Errorf("failed to check Notion for %s: %w", summary.VideoID, err)
+		}
+		if exists {
+			skipped++
+			continue
+		}
+
+		if err := notionClient.CreateSummaryPage(ctx, summary); err != nil {
+			return fmt.Errorf("failed to export %s to Notion: %w", summary.VideoID, err)
+		}
+		exported++
+	}
+
+	appLogger.Info("Notion export completed", "exported", exported, "alreadyPresent", skipped)
+	return nil
+}
+
+// serveUI serves the HTTP endpoints used by the web UI until the process is stopped
+func serveUI(cfg *types.Config, addr string, appLogger *logger.Logger) error {
+	if cfg.Storage.ThumbnailDir == "" {
+		return fmt.Errorf("storage.thumbnail_dir must be set to serve thumbnails")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /thumb/{videoID}", clients.NewThumbnailCache(cfg.Storage.ThumbnailDir, clients.NewHTTPClient(cfg.Timeouts.YouTube, cfg.HTTP.Proxy, clients.RetryPolicyFromConfig(cfg.HTTP)), appLogger))
+
+	appLogger.Info("Serving HTTP UI", "addr", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// sendWeeklyRoundup emails every summary from the past 7 days grouped by channel
+func sendWeeklyRoundup(ctx context.Context, app *App) error {
+	if app.emailService == nil {
+		return fmt.Errorf("weekly roundup needs email credentials (EMAIL_USERNAME and EMAIL_PASSWORD)")
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -7)
+
+	summaries, err := app.storage.GetSummariesByDateRange(ctx, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to get summaries for weekly roundup: %w", err)
+	}
+
+	// Skipped videos have no summary to show
+	var summarized []types.Summary
+	for _, summary := range summaries {
+		if summary.Status != "Skipped" {
+			summarized = append(summarized, summary)
+		}
+	}
+
+	if err := app.emailService.SendWeeklyRoundup(ctx, summarized, start, end); err != nil {
+		return fmt.Errorf("failed to send weekly roundup: %w", err)
+	}
+	return nil
+}
+
+// pruneProcessedVideos clears processed-video records for one channel (by ID or name) or for all channels
+func pruneProcessedVideos(ctx context.Context, dataStorage types.Storage, channel string, appLogger *logger.Logger) error {
+	channelID := ""
+	if channel != "" {
+		channels, err := dataStorage.GetChannels(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get channels: %w", err)
+		}
+		for _, ch := range channels {
+			if ch.ID == channel || strings.EqualFold(ch.Name, channel) {
+				channelID = ch.ID
+				break
+			}
+		}
+		if channelID == "" {
+			return fmt.Errorf("unknown channel: %s", channel)
+		}
+	}
+
+	cleared, err := dataStorage.ClearProcessedVideos(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to clear processed videos: %w", err)
+	}
+
+	appLogger.Info("Pruned processed videos", "channelID", channelID, "count", cleared)
+	return nil
+}
+
+// compactStorage compacts the data file and prints how much smaller it got
+func compactStorage(ctx context.Context, dataStorage types.Storage, storageType, excelPath string) error {
+	if storageType != "excel" {
+		fmt.Println("Nothing to compact for in-memory storage")
+		return nil
+	}
+
+	before, err := os.Stat(excelPath)
+	if err != nil {
+		return fmt.Errorf("failed to read Excel file size: %w", err)
+	}
+	if err := dataStorage.Compact(ctx); err != nil {
+		return fmt.Errorf("failed to compact Excel file: %w", err)
+	}
+	after, err := os.Stat(excelPath)
+	if err != nil {
+		return fmt.Errorf("failed to read Excel file size: %w", err)
+	}
+
+	fmt.Printf("Compacted %s: %d KB -> %d KB\n", excelPath, before.Size()/1024, after.Size()/1024)
+	return nil
+}
+
+// repairExcelFile rebuilds the Excel data file and prints what was recovered
+func repairExcelFile(cfg *types.Config, storageType, excelPath string, appLogger *logger.Logger) error {
+	if storageType != "excel" {
+		return fmt.Errorf("-repair only applies to excel storage")
+	}
+
+	excelStorage := storage.NewExcelStorage(excelPath, appLogger)
+	excelStorage.SetSaveRetry(cfg.Storage.SaveRetries+1, cfg.Storage.SaveRetryDelay)
+	// No rotating backup here: Repair recovers from the backups and keeps the damaged
+	// original as its own copy, so a backup would only push out a good one
+	result, err := excelStorage.Repair()
+	if err != nil {
+		return fmt.Errorf("failed to repair Excel file: %w", err)
+	}
+
+	if result.CorruptCopy != "" {
+		fmt.Printf("Original file kept as %s\n", result.CorruptCopy)
+	}
+	for _, sheet := range result.Sheets {
+		if sheet.Source == "" {
+			fmt.Printf("%-16s nothing recovered\n", sheet.Sheet)
+			continue
+		}
+		fmt.Printf("%-16s %d rows from %s\n", sheet.Sheet, sheet.Rows, sheet.Source)
+	}
+	return nil
+}
+
+// listStoredSummaries prints a table of summaries matching the filter
+func listStoredSummaries(ctx context.Context, dataStorage types.Storage, filter types.SummaryFilter) error {
+	var summaries []types.Summary
+	var err error
+	if !filter.Since.IsZero() {
+		summaries, err = dataStorage.GetSummariesByDateRange(ctx, filter.Since, time.Now())
+	} else {
+		summaries, _, err = dataStorage.GetAllSummaries(ctx, 0, 0)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get summaries: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tSTATUS\tCHANNEL\tTITLE")
+	count := 0
+	for _, summary := range summaries {
+		if !filter.Matches(summary) {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			summary.ID,
+			summary.CreatedAt.Format("2006-01-02 15:04"),
+			summary.Status,
+			summary.ChannelName,
+			summary.VideoTitle)
+		count++
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d summaries\n", count)
+	return nil
+}
+
+// listVideoFailures prints the recorded failed videos, newest first, leaving out
+// videos that have been summarized since
+func listVideoFailures(ctx context.Context, dataStorage types.Storage) error {
+	failures, err := dataStorage.GetVideoFailures(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get failed videos: %w", err)
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].FailedAt.After(failures[j].FailedAt)
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FAILED\tATTEMPTS\tVIDEO\tCHANNEL\tTITLE\tERROR")
+	count := 0
+	for _, failure := range failures {
+		processed, err := dataStorage.IsVideoProcessed(ctx, failure.VideoID)
+		if err != nil {
+			return fmt.Errorf("failed to check video %s: %w", failure.VideoID, err)
+		}
+		if processed {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			failure.FailedAt.Format("2006-01-02 15:04"),
+			failure.Attempts,
+			failure.VideoID,
+			failure.Channel,
+			failure.Title,
+			failure.Error)
+		count++
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d failed videos\n", count)
+	return nil
+}
+
+// runChannelCommand runs the add-channel or list-channels subcommand, which only
+// need the configuration and storage
+func runChannelCommand(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configPath := fs.String("config", "configs/config.yaml", "Path to configuration file")
+	envPath := fs.String("env", ".env", "Path to environment file")
+	excelPath := fs.String("excel", "youtube-data.xlsx", "Path to Excel data file")
+	storageType := fs.String("storage", "excel", "Storage backend: excel or memory")
+	development := fs.Bool("dev", false, "Run in development mode")
+	var channel types.Channel
+	if name == "add-channel" {
+		fs.StringVar(&channel.ID, "id", "", "Channel ID (UC...) or @handle")
+		fs.StringVar(&channel.Name, "name", "", "Channel name shown in the digest")
+		fs.StringVar(&channel.Username, "username", "", "Channel username or @handle (optional)")
+		fs.StringVar(&channel.Group, "group", "", "Channel group (optional)")
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if name == "add-channel" {
+		channel.ID = strings.TrimSpace(channel.ID)
+		channel.Name = strings.TrimSpace(channel.Name)
+		if !clients.IsChannelID(channel.ID) && !clients.IsChannelHandle(channel.ID) {
+			return fmt.Errorf("invalid channel ID %q: expected UC followed by 22 characters, or an @handle", channel.ID)
+		}
+		if channel.Name == "" {
+			return fmt.Errorf("-name is required")
+		}
+	}
+
+	appLogger, err := logger.New(*development)
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer appLogger.Sync()
+
+	if err := godotenv.Load(*envPath); err != nil {
+		appLogger.Warn("Failed to load .env file (continuing with environment variables)", "error", err)
+	}
+
+	cfg, err := config.NewLoader(*configPath, *envPath).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	dataStorage, err := initializeStorage(cfg, *storageType, *excelPath, name != "list-channels", appLogger)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if name == "list-channels" {
+		return listChannels(ctx, dataStorage)
+	}
+
+	if err := dataStorage.AddChannel(ctx, channel); err != nil {
+		if errors.Is(err, types.ErrDuplicateChannel) {
+			return fmt.Errorf("channel %s is already listed", channel.ID)
+		}
+		return fmt.Errorf("failed to add channel: %w", err)
+	}
+	fmt.Printf("Added channel %s (%s)\n", channel.Name, channel.ID)
+	return nil
+}
+
+// listChannels prints the configured channels as a table
+func listChannels(ctx context.Context, dataStorage types.Storage) error {
+	channels, err := dataStorage.GetChannels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get channels: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tUSERNAME\tGROUP")
+	for _, channel := range channels {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", channel.ID, channel.Name, channel.Username, channel.Group)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d channels\n", len(channels))
+	return nil
+}
+
+// modelPrice is a model's list price in USD per million input and output tokens
+type modelPrice struct {
+	input, output float64
+}
+
+// modelPrices are list prices by model name prefix, checked in order so longer
+// prefixes come first. Provider names price summaries that recorded no model at
+// the provider's default model.
+var modelPrices = []struct {
+	prefix string
+	price  modelPrice
+}{
+	{"claude-opus", modelPrice{15, 75}},
+	{"claude-sonnet", modelPrice{3, 15}},
+	{"claude-3-opus", modelPrice{15, 75}},
+	{"claude-3-5-sonnet", modelPrice{3, 15}},
+	{"claude-3-7-sonnet", modelPrice{3, 15}},
+	{"claude-3-5-haiku", modelPrice{0.8, 4}},
+	{"claude-3-haiku", modelPrice{0.25, 1.25}},
+	{"gpt-4o-mini", modelPrice{0.15, 0.6}},
+	{"gpt-4o", modelPrice{2.5, 10}},
+	{"gpt-4.1-nano", modelPrice{0.1, 0.4}},
+	{"gpt-4.1-mini", modelPrice{0.4, 1.6}},
+	{"gpt-4.1", modelPrice{2, 8}},
+	{"claude", modelPrice{3, 15}},
+	{"openai", modelPrice{0.15, 0.6}},
+}
+
+// priceFor returns the list price of a model, or false when it's unknown
+func priceFor(model string) (modelPrice, bool) {
+	for _, entry := range modelPrices {
+		if strings.HasPrefix(model, entry.prefix) {
+			return entry.price, true
+		}
+	}
+	return modelPrice{}, false
+}
+
+// estimateCost prices token usage by model, returning the models it has no price for
+func estimateCost(tokensByModel map[string]types.TokenUsage) (cost float64, unpriced []string) {
+	for model, usage := range tokensByModel {
+		price, ok := priceFor(model)
+		if !ok {
+			if model == "" {
+				model = "unknown"
+			}
+			unpriced = append(unpriced, model)
+			continue
+		}
+		cost += float64(usage.Input)/1e6*price.input + float64(usage.Output)/1e6*price.output
+	}
+	sort.Strings(unpriced)
+	return cost, unpriced
+}
+
+// printSummaryStats prints totals over every stored summary: counts by status and
+// channel, recent activity, average length and the estimated AI tokens and cost
+func printSummaryStats(ctx context.Context, dataStorage types.Storage) error {
+	stats, err := dataStorage.GetSummaryAggregates(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to aggregate summaries: %w", err)
+	}
+
+	statuses := make([]string, 0, len(stats.ByStatus))
+	for status, count := range stats.ByStatus {
+		statuses = append(statuses, fmt.Sprintf("%s %d", status, count))
+	}
+	sort.Strings(statuses)
+
+	averageChars := 0
+	if stats.Summarized > 0 {
+		averageChars = stats.SummaryChars / stats.Summarized
+	}
+	cost, unpriced := estimateCost(stats.TokensByModel)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if len(statuses) > 0 {
+		fmt.Fprintf(w, "Summaries:\t%d (%s)\n", stats.Total, strings.Join(statuses, ", "))
+	} else {
+		fmt.Fprintf(w, "Summaries:\t%d\n", stats.Total)
+	}
+	fmt.Fprintf(w, "Last 7 days:\t%d\n", stats.Last7Days)
+	fmt.Fprintf(w, "Last 30 days:\t%d\n", stats.Last30Days)
+	fmt.Fprintf(w, "Average length:\t%d characters\n", averageChars)
+	fmt.Fprintf(w, "Estimated tokens:\t%d input, %d output\n", stats.InputTokens, stats.OutputTokens)
+	if len(unpriced) > 0 {
+		fmt.Fprintf(w, "Estimated cost:\t$%.2f at list prices (not counting %s)\n", cost, strings.Join(unpriced, ", "))
+	} else {
+		fmt.Fprintf(w, "Estimated cost:\t$%.2f at list prices\n", cost)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	channels := make([]string, 0, len(stats.ByChannel))
+	for channel := range stats.ByChannel {
+		channels = append(channels, channel)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		if stats.ByChannel[channels[i]] != stats.ByChannel[channels[j]] {
+			return stats.ByChannel[channels[i]] > stats.ByChannel[channels[j]]
+		}
+		return channels[i] < channels[j]
+	})
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANNEL\tSUMMARIES")
+	for _, channel := range channels {
+		fmt.Fprintf(w, "%s\t%d\n", channel, stats.ByChannel[channel])
+	}
+	return w.Flush()
+}
+
+// parseAge parses an age such as "7d" or "12h"; a "d" suffix means days
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days: %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
 // Removed shouldSendEmail - no longer needed for on-demand processing
 
 // printHelp prints usage information
@@ -221,21 +1289,60 @@ DESCRIPTION:
 
 USAGE:
     %s [OPTIONS]
+    %s add-channel -id <UC... or @handle> -name <name> [-username <name>] [-group <group>]
+    %s list-channels
+
+SUBCOMMANDS:
+    add-channel       Add a channel to the Channels sheet, then exit
+    list-channels     List the configured channels, then exit
+    Both accept -config, -env, -excel, -storage and -dev
 
 OPTIONS:
     -config string    Path to configuration file (default: "configs/config.yaml")
+    -config-dir string  Directory of per-profile configs (<name>.yaml); runs every profile
+    -profile string   Run only the named profile from -config-dir
     -env string       Path to environment file (default: ".env")
     -excel string     Path to Excel data file (default: "youtube-data.xlsx")
+    -storage string   Storage backend: excel or memory (default: "excel")
+    -init             Create a commented config file, .env template and data file, then exit
+    -force            With -init: overwrite existing config and .env files
     -test-email       Send test email and exit
+    -weekly-roundup   Email a roundup of the past 7 days' summaries grouped by channel and exit
+    -export-notion    Export stored summaries to the Notion database and exit
+    -test-transcript string
+                      Fetch and print the transcript for this video ID, then exit
+    -preview-channel string
+                      List a channel's recent videos (ID or @handle) without processing them, then exit
+    -since-last-run   Only consider videos published since the last successful run (one with no
+                      failed videos or channels); new channels still get the first-run limit
+    -resume           Skip the channels an interrupted run already finished
+    -timeout duration Stop the run after this long, e.g. 50m (overrides processing.run_timeout)
+    -run-log string   Append a JSON summary of each run (videos, tokens, email sent) to this file
+    -serve string     Serve the HTTP UI endpoints (GET /thumb/<videoID>) on this address
+    -resummarize-model-before string
+                      Regenerate summaries written by another model (e.g. claude-sonnet-4-20250514),
+                      or created before a date (YYYY-MM-DD), keeping their status, and exit
+    -repair           Rebuild a corrupted Excel file from its readable rows and backups, then exit
+    -compact          Rewrite the Excel file without blank rows and gaps, then exit
+    -prune-processed  Forget processed videos so they are summarized again, and exit
+        -channel string   Only this channel (ID or name); default is all channels
+    -list-summaries   List stored summaries and exit, filtered by:
+        -status string    Only this status (New, Processed, Skipped, Removed)
+        -channel string   Only channels whose name contains this text
+        -since string     Only summaries newer than this age (e.g. 7d, 12h)
+    -list-failures    List videos that failed every retry (with the error) and exit
+    -stats            Print totals over all stored summaries and exit
     -dev              Run in development mode with verbose logging
     -help             Show this help message
 
 ENVIRONMENT VARIABLES:
     YOUTUBE_API_KEY    YouTube Data API v3 key (required)
-    CLAUDE_API_KEY     Claude API key for summarization (required)
+    CLAUDE_API_KEY     Claude API key for summarization (required with the claude provider)
+    OPENAI_API_KEY     OpenAI API key (required when ai.providers includes openai)
     RAPID_API_KEY      RapidAPI key for transcript fetching (optional)
     EMAIL_USERNAME     Email username for SMTP (optional)
     EMAIL_PASSWORD     Email password for SMTP (optional)
+    NOTION_API_KEY     Notion integration token for -export-notion (optional)
 
 EXAMPLES:
     # Process new videos and send digest
@@ -250,6 +1357,12 @@ EXAMPLES:
     # Use custom configuration and data files
     %s -config ./my-config.yaml -excel ./my-data.xlsx
 
+    # Run only the "family" profile from a directory of profiles
+    %s -config-dir ./profiles -profile family
+
+    # List this week's unsent summaries
+    %s -list-summaries -status New -since 7d
+
 NOTES:
     This application runs once and exits. It processes all new videos from
     configured channels and optionally sends an email digest.
@@ -261,5 +1374,5 @@ NOTES:
 
 DOCUMENTATION:
     For detailed setup instructions, see README.md
-`, filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]))
+`, filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]), filepath.Base(os.Args[0]))
 }
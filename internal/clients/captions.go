@@ -0,0 +1,224 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// AlternativeTranscriptClient reads the public caption tracks of a video from
+// YouTube directly; it's the fallback when RapidAPI fails or no key is set
+type AlternativeTranscriptClient struct {
+	httpClient *HTTPClient
+	logger     types.Logger
+	// languages are the preferred caption languages in order, as in transcript.languages
+	languages []string
+}
+
+// NewAlternativeTranscriptClient creates a fallback transcript client
+func NewAlternativeTranscriptClient(httpClient *HTTPClient, logger types.Logger) *AlternativeTranscriptClient {
+	return &AlternativeTranscriptClient{
+		httpClient: httpClient,
+		logger:     logger,
+		languages:  []string{transcriptLang},
+	}
+}
+
+// SetLanguages sets the preferred caption languages, tried in order
+func (atc *AlternativeTranscriptClient) SetLanguages(languages []string) {
+	if len(languages) > 0 {
+		atc.languages = languages
+	}
+}
+
+// GetTranscript fetches the captions of a YouTube video as plain text
+func (atc *AlternativeTranscriptClient) GetTranscript(ctx context.Context, videoID string) (string, error) {
+	data, err := atc.GetTranscriptWithThumbnail(ctx, videoID)
+	if err != nil {
+		return "", err
+	}
+	return data.Transcript, nil
+}
+
+// GetTranscriptWithThumbnail fetches the captions of a YouTube video and its thumbnail
+func (atc *AlternativeTranscriptClient) GetTranscriptWithThumbnail(ctx context.Context, videoID string) (*types.TranscriptData, error) {
+	data, err := atc.getAlternativeTranscriptWithThumbnail(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	data.Source = types.SourceAltTranscript
+	return data, nil
+}
+
+// captionTrack is a caption track listed in a watch page's player response
+type captionTrack struct {
+	BaseURL      string `json:"baseUrl"`
+	LanguageCode string `json:"languageCode"`
+	Kind         string `json:"kind"` // "asr" for automatic captions
+}
+
+// timedText is a timedtext caption document: one <text> element per cue
+type timedText struct {
+	Cues []struct {
+		Text string `xml:",chardata"`
+	} `xml:"text"`
+}
+
+// maxWatchPageSize bounds how much of a watch page is read
+const maxWatchPageSize = 8 << 20
+
+// getAlternativeTranscriptWithThumbnail finds the video's caption tracks on its watch
+// page and converts the best one to plain text. It fails with types.ErrTranscriptUnavailable
+// only when the video has no captions at all.
+func (atc *AlternativeTranscriptClient) getAlternativeTranscriptWithThumbnail(ctx context.Context, videoID string) (*types.TranscriptData, error) {
+	tracks, err := atc.captionTracks(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no captions for video %s: %w", videoID, types.ErrTranscriptUnavailable)
+	}
+
+	track := pickCaptionTrack(tracks, atc.languages)
+	atc.logger.Debug("Fetching YouTube captions", "videoID", videoID, "lang", track.LanguageCode, "kind", track.Kind)
+
+	cues, err := atc.fetchCaptions(ctx, track.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch captions for video %s: %w", videoID, err)
+	}
+
+	transcript := strings.Join(cues, " ")
+	if transcript == "" {
+		return nil, fmt.Errorf("empty captions for video %s: %w", videoID, types.ErrTranscriptUnavailable)
+	}
+
+	atc.logger.Info("Retrieved transcript from YouTube captions",
+		"videoID", videoID,
+		"length", len(transcript),
+		"segments", len(cues),
+		"lang", track.LanguageCode)
+
+	return &types.TranscriptData{
+		Transcript:   transcript,
+		ThumbnailURL: fmt.Sprintf("https://img.youtube.com/vi/%s/hqdefault.jpg", videoID),
+		SegmentCount: len(cues),
+		Language:     track.LanguageCode,
+	}, nil
+}
+
+// captionTracks reads the caption tracks listed in a video's watch page
+func (atc *AlternativeTranscriptClient) captionTracks(ctx context.Context, videoID string) ([]captionTrack, error) {
+	params := url.Values{}
+	params.Add("v", videoID)
+	params.Add("hl", "en")
+
+	resp, err := atc.httpClient.Get(ctx, "https://www.youtube.com/watch?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch watch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("YouTube watch page", resp.StatusCode, "")
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxWatchPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch page: %w", err)
+	}
+
+	return parseCaptionTracks(page)
+}
+
+// parseCaptionTracks reads the caption tracks from a watch page. A page whose player
+// response lists no tracks returns none; a page without a player response at all
+// (e.g. a consent or bot-check page) is an error, since it says nothing about captions.
+func parseCaptionTracks(page []byte) ([]captionTrack, error) {
+	const marker = `"captionTracks":`
+	i := strings.Index(string(page), marker)
+	if i < 0 {
+		if strings.Contains(string(page), "ytInitialPlayerResponse") && strings.Contains(string(page), `"playabilityStatus"`) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("watch page has no player response")
+	}
+
+	// The decoder stops after the array, ignoring the rest of the page
+	var tracks []captionTrack
+	if err := json.NewDecoder(strings.NewReader(string(page[i+len(marker):]))).Decode(&tracks); err != nil {
+		return nil, fmt.Errorf("failed to decode caption tracks: %w", err)
+	}
+	return tracks, nil
+}
+
+// pickCaptionTrack returns the track in the first preferred language that has one,
+// favouring manual captions over automatic ones, or else the first track
+func pickCaptionTrack(tracks []captionTrack, languages []string) captionTrack {
+	for _, want := range languages {
+		if want == autoTranscriptLang {
+			break
+		}
+		var automatic *captionTrack
+		for i, track := range tracks {
+			if !strings.EqualFold(track.LanguageCode, want) && !strings.HasPrefix(strings.ToLower(track.LanguageCode), strings.ToLower(want)+"-") {
+				continue
+			}
+			if track.Kind != "asr" {
+				return track
+			}
+			if automatic == nil {
+				automatic = &tracks[i]
+			}
+		}
+		if automatic != nil {
+			return *automatic
+		}
+	}
+	return tracks[0]
+}
+
+// fetchCaptions downloads a caption track in the timedtext XML format and returns
+// its cues as plain text
+func (atc *AlternativeTranscriptClient) fetchCaptions(ctx context.Context, baseURL string) ([]string, error) {
+	captionURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid caption URL: %w", err)
+	}
+	// Without fmt the endpoint returns the simple <transcript><text> format
+	query := captionURL.Query()
+	query.Del("fmt")
+	captionURL.RawQuery = query.Encode()
+
+	resp, err := atc.httpClient.Get(ctx, captionURL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("YouTube timedtext", resp.StatusCode, "")
+	}
+
+	var doc timedText
+	if err := xml.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode captions: %w", err)
+	}
+
+	cues := make([]string, 0, len(doc.Cues))
+	for _, cue := range doc.Cues {
+		// Cue text is HTML-escaped a second time inside the XML (e.g. &amp;#39;)
+		text := strings.Join(strings.Fields(html.UnescapeString(cue.Text)), " ")
+		if text != "" {
+			cues = append(cues, text)
+		}
+	}
+	return cues, nil
+}
@@ -0,0 +1,25 @@
+package clients
+
+import "testing"
+
+func TestParseCaptionTracks(t *testing.T) {
+	withTracks := `<script>var ytInitialPlayerResponse = {"playabilityStatus":{"status":"OK"},"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[{"baseUrl":"https://www.youtube.com/api/timedtext?v=abc","languageCode":"en","kind":"asr"}]}}};</script>`
+	tracks, err := parseCaptionTracks([]byte(withTracks))
+	if err != nil {
+		t.Fatalf("parseCaptionTracks() error = %v", err)
+	}
+	if len(tracks) != 1 || tracks[0].LanguageCode != "en" || tracks[0].Kind != "asr" {
+		t.Errorf("parseCaptionTracks() = %+v", tracks)
+	}
+
+	withoutTracks := `<script>var ytInitialPlayerResponse = {"playabilityStatus":{"status":"OK"},"videoDetails":{"videoId":"abc"}};</script>`
+	tracks, err = parseCaptionTracks([]byte(withoutTracks))
+	if err != nil || len(tracks) != 0 {
+		t.Errorf("player response without captions: parseCaptionTracks() = %v, %v, want no tracks and no error", tracks, err)
+	}
+
+	consentPage := `<html><form action="https://consent.youtube.com/save">Before you continue to YouTube</form></html>`
+	if _, err := parseCaptionTracks([]byte(consentPage)); err == nil {
+		t.Error("page without a player response: parseCaptionTracks() succeeded, want an error")
+	}
+}
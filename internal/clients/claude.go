@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"youtube-summarizer/pkg/types"
@@ -19,24 +21,39 @@ type ClaudeClient struct {
 	baseURL    string
 	model      string
 	logger     types.Logger
+	// requestLogger, when set, receives full prompts and raw responses (ai.log_requests)
+	requestLogger types.Logger
+	calls         atomic.Int64 // Messages API requests made
+	tokens        atomic.Int64 // input plus output tokens used
+	throttle      *rateLimitThrottle
+	// promptTemplate is used when a call passes no template (ai.summary_prompt)
+	promptTemplate string
+	maxTokens      int
+	temperature    float64
+	// rateLimitRetries is how often a 429 response is retried (ai.rate_limit_retries)
+	rateLimitRetries int
 }
 
 // NewClaudeClient creates a new Claude API client
-func NewClaudeClient(apiKey string, logger types.Logger) *ClaudeClient {
+func NewClaudeClient(apiKey string, httpClient *HTTPClient, logger types.Logger) *ClaudeClient {
 	return &ClaudeClient{
-		httpClient: NewHTTPClient(60 * time.Second), // Longer timeout for AI requests
-		apiKey:     apiKey,
-		baseURL:    "https://api.anthropic.com/v1",
-		model:      "claude-sonnet-4-20250514", // Latest Claude model from official docs
-		logger:     logger,
+		httpClient:  httpClient,
+		apiKey:      apiKey,
+		baseURL:     "https://api.anthropic.com/v1",
+		model:       "claude-sonnet-4-20250514", // Latest Claude model from official docs
+		logger:      logger,
+		throttle:    &rateLimitThrottle{logger: logger},
+		maxTokens:   1000, // Reasonable limit for summary
+		temperature: 1,
 	}
 }
 
 // ClaudeRequest represents the request structure for Claude API
 type ClaudeRequest struct {
-	Model     string          `json:"model"`
-	MaxTokens int             `json:"max_tokens"`
-	Messages  []ClaudeMessage `json:"messages"`
+	Model       string          `json:"model"`
+	MaxTokens   int             `json:"max_tokens"`
+	Temperature float64         `json:"temperature"`
+	Messages    []ClaudeMessage `json:"messages"`
 }
 
 // ClaudeMessage represents a message in the conversation
@@ -77,6 +94,19 @@ type ClaudeErrorDetail struct {
 
 // Summarize generates a summary of the video transcript using Claude
 func (cc *ClaudeClient) Summarize(ctx context.Context, transcript, title string) (string, error) {
+	return cc.SummarizeWithPrompt(ctx, "", transcript, title)
+}
+
+// SummarizeWithPrompt generates a summary using the given prompt template.
+// The template may contain {title} and {transcript} placeholders; an empty
+// template uses the built-in default prompt.
+func (cc *ClaudeClient) SummarizeWithPrompt(ctx context.Context, promptTemplate, transcript, title string) (string, error) {
+	return cc.SummarizeWithExamples(ctx, promptTemplate, transcript, title, nil)
+}
+
+// SummarizeWithExamples is SummarizeWithPrompt preceded by few-shot examples, each
+// sent as a user turn (the example input in the same prompt) and an assistant reply
+func (cc *ClaudeClient) SummarizeWithExamples(ctx context.Context, promptTemplate, transcript, title string, examples []types.AIExample) (string, error) {
 	// Truncate transcript if it's too long
 	maxLength := 50000 // Conservative limit for Claude input
 	if len(transcript) > maxLength {
@@ -85,22 +115,25 @@ func (cc *ClaudeClient) Summarize(ctx context.Context, transcript, title string)
 	}
 
 	// Create the prompt
-	prompt := fmt.Sprintf(`Video Title: "%s"
-
-Summarize the key takeaways from the following youtubevideo into a concise paragraph. Focus on the main news events and the most important information:
-
-%s`, title, transcript)
+	if promptTemplate == "" {
+		promptTemplate = cc.promptTemplate
+	}
+	prompt := buildPrompt(promptTemplate, transcript, title)
 
 	// Prepare the request
+	var messages []ClaudeMessage
+	for _, example := range examples {
+		messages = append(messages,
+			ClaudeMessage{Role: "user", Content: buildPrompt(promptTemplate, example.Input, example.Title)},
+			ClaudeMessage{Role: "assistant", Content: example.Output})
+	}
+	messages = append(messages, ClaudeMessage{Role: "user", Content: prompt})
+
 	request := ClaudeRequest{
-		Model:     cc.model,
-		MaxTokens: 1000, // Reasonable limit for summary
-		Messages: []ClaudeMessage{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
+		Model:       cc.model,
+		MaxTokens:   cc.maxTokens,
+		Temperature: cc.temperature,
+		Messages:    messages,
 	}
 
 	requestBody, err := json.Marshal(request)
@@ -109,36 +142,26 @@ Summarize the key takeaways from the following youtubevideo into a concise parag
 	}
 
 	cc.logger.Debug("Sending request to Claude API", "videoTitle", title, "transcriptLength", len(transcript))
-
-	// Make the API request
-	req, err := http.NewRequestWithContext(ctx, "POST", cc.baseURL+"/messages", bytes.NewBuffer(requestBody))
-	if err != nil {
-		return "", fmt.Errorf("failed to create Claude API request: %w", err)
+	if cc.requestLogger != nil {
+		cc.requestLogger.Debug("Claude API request", "videoTitle", title, "model", cc.model, "examples", len(examples), "prompt", prompt)
 	}
 
-	// Set headers according to official Anthropic API docs
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", cc.apiKey)
-	req.Header.Set("anthropic-version", "2023-06-01")
-
-	resp, err := cc.httpClient.DoWithContext(ctx, req)
+	// Make the API request
+	resp, body, err := cc.post(ctx, requestBody, title)
 	if err != nil {
-		return "", fmt.Errorf("failed to call Claude API: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 
 	// Handle non-200 responses
 	if resp.StatusCode != http.StatusOK {
 		var claudeError ClaudeError
-		if err := json.NewDecoder(resp.Body).Decode(&claudeError); err == nil {
-			return "", fmt.Errorf("claude API error (%d): %s", resp.StatusCode, claudeError.Error.Message)
-		}
-		return "", fmt.Errorf("claude API returned status %d", resp.StatusCode)
+		_ = json.Unmarshal(body, &claudeError)
+		return "", claudeStatusError(resp.StatusCode, claudeError)
 	}
 
 	// Parse the response
 	var claudeResponse ClaudeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&claudeResponse); err != nil {
+	if err := json.Unmarshal(body, &claudeResponse); err != nil {
 		return "", fmt.Errorf("failed to decode Claude API response: %w", err)
 	}
 
@@ -147,11 +170,13 @@ Summarize the key takeaways from the following youtubevideo into a concise parag
 		return "", fmt.Errorf("claude API returned empty content")
 	}
 
-	summary := strings.TrimSpace(claudeResponse.Content[0].Text)
-	if summary == "" {
-		return "", fmt.Errorf("claude API returned empty summary")
+	summary, err := responseText(claudeResponse.Content)
+	if err != nil {
+		return "", err
 	}
 
+	cc.tokens.Add(int64(claudeResponse.Usage.InputTokens + claudeResponse.Usage.OutputTokens))
+
 	cc.logger.Info("Generated summary using Claude",
 		"videoTitle", title,
 		"inputTokens", claudeResponse.Usage.InputTokens,
@@ -161,13 +186,192 @@ Summarize the key takeaways from the following youtubevideo into a concise parag
 	return summary, nil
 }
 
+// responseText joins the text blocks of a response, skipping other block types
+// (e.g. tool_use) that may come before or between them
+func responseText(content []ClaudeContent) (string, error) {
+	var parts []string
+	for _, block := range content {
+		if block.Type != "text" {
+			continue
+		}
+		if text := strings.TrimSpace(block.Text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", fmt.Errorf("claude API returned no text in %d content blocks", len(content))
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// post sends a Messages API request and reads the response, retrying a rate-limited
+// (429) response up to rateLimitRetries times after the delay the API asks for. The
+// last response is returned as is, so a final 429 is reported like any other error.
+func (cc *ClaudeClient) post(ctx context.Context, requestBody []byte, title string) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", cc.baseURL+"/messages", bytes.NewReader(requestBody))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create Claude API request: %w", err)
+		}
+
+		// Set headers according to official Anthropic API docs
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", cc.apiKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+
+		if err := cc.throttle.wait(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to call Claude API: %w", err)
+		}
+
+		cc.calls.Add(1)
+		resp, err := cc.httpClient.DoWithContext(ctx, req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to call Claude API: %w", err)
+		}
+		cc.throttle.observeAnthropic(resp.Header)
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read Claude API response: %w", err)
+		}
+		if cc.requestLogger != nil {
+			cc.requestLogger.Debug("Claude API response", "videoTitle", title, "status", resp.StatusCode, "body", string(body))
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= cc.rateLimitRetries {
+			return resp, body, nil
+		}
+
+		// Give up early rather than sleep past the deadline
+		delay := rateLimitDelay(resp.Header, time.Now())
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, body, nil
+		}
+
+		cc.logger.Warn("Claude API rate limited, retrying", "videoTitle", title, "attempt", attempt+1, "delay", delay.Round(time.Second).String())
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, fmt.Errorf("failed to call Claude API: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// defaultRateLimitDelay is the wait after a 429 response that says nothing about
+// when to retry
+const defaultRateLimitDelay = 10 * time.Second
+
+// rateLimitDelay returns how long a 429 response asks to wait: its Retry-After
+// seconds, or else the latest anthropic-ratelimit-*-reset time
+func rateLimitDelay(header http.Header, now time.Time) time.Duration {
+	if seconds, ok := headerInt(header, "Retry-After"); ok && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	var delay time.Duration
+	for _, name := range []string{"requests", "tokens", "input-tokens", "output-tokens"} {
+		resetAt, err := time.Parse(time.RFC3339, header.Get("anthropic-ratelimit-"+name+"-reset"))
+		if err == nil && resetAt.Sub(now) > delay {
+			delay = resetAt.Sub(now)
+		}
+	}
+	if delay <= 0 {
+		return defaultRateLimitDelay
+	}
+	return delay
+}
+
+// buildPrompt fills a prompt template's {title} and {transcript} placeholders,
+// using the built-in default prompt when the template is empty
+func buildPrompt(promptTemplate, transcript, title string) string {
+	if promptTemplate == "" {
+		promptTemplate = types.DefaultPromptTemplate
+	}
+	return strings.NewReplacer("{title}", title, "{transcript}", transcript).Replace(promptTemplate)
+}
+
 // SetModel allows changing the Claude model used for summarization
 func (cc *ClaudeClient) SetModel(model string) {
 	cc.model = model
 	cc.logger.Debug("Changed Claude model", "model", model)
 }
 
+// SetPromptTemplate sets the template used when a call passes none; {title} and
+// {transcript} are filled in, and an empty template restores the built-in prompt
+func (cc *ClaudeClient) SetPromptTemplate(promptTemplate string) {
+	cc.promptTemplate = promptTemplate
+}
+
+// SetSampling sets the response token limit and the sampling temperature
+func (cc *ClaudeClient) SetSampling(maxTokens int, temperature float64) {
+	cc.maxTokens = maxTokens
+	cc.temperature = temperature
+}
+
+// SetRateLimitRetries sets how many times a rate-limited request is retried
+func (cc *ClaudeClient) SetRateLimitRetries(retries int) {
+	cc.rateLimitRetries = retries
+}
+
+// SetThrottle pauses requests until the rate limit resets once fewer than minRequests
+// requests or minTokens tokens remain (0 disables either check)
+func (cc *ClaudeClient) SetThrottle(minRequests, minTokens int) {
+	cc.throttle.minRequests = minRequests
+	cc.throttle.minTokens = minTokens
+}
+
+// SetRequestLogger enables logging of full prompts and raw responses at debug level
+func (cc *ClaudeClient) SetRequestLogger(logger types.Logger) {
+	cc.requestLogger = logger
+}
+
 // GetModel returns the current Claude model being used
 func (cc *ClaudeClient) GetModel() string {
 	return cc.model
 }
+
+// Name returns the provider name used in ai.providers
+func (cc *ClaudeClient) Name() string {
+	return "claude"
+}
+
+// APICalls returns the number of Claude API requests made so far and the tokens they used
+func (cc *ClaudeClient) APICalls() []types.APICallCount {
+	return []types.APICallCount{{API: "Claude", Calls: cc.calls.Load(), Tokens: cc.tokens.Load()}}
+}
+
+// MockAIClient for testing purposes
+type MockAIClient struct {
+	logger        types.Logger
+	requestLogger types.Logger
+}
+
+// NewMockAIClient creates a mock AI client that returns deterministic summaries
+func NewMockAIClient(logger types.Logger) *MockAIClient {
+	return &MockAIClient{logger: logger}
+}
+
+// Summarize returns a deterministic mock summary
+func (mac *MockAIClient) Summarize(ctx context.Context, transcript, title string) (string, error) {
+	return mac.SummarizeWithPrompt(ctx, "", transcript, title)
+}
+
+// SummarizeWithPrompt returns a deterministic mock summary, ignoring the prompt template
+func (mac *MockAIClient) SummarizeWithPrompt(ctx context.Context, promptTemplate, transcript, title string) (string, error) {
+	mac.logger.Debug("Using mock AI summary", "videoTitle", title)
+	summary := fmt.Sprintf("Mock summary of %q based on %d characters of transcript.", title, len(transcript))
+	if mac.requestLogger != nil {
+		mac.requestLogger.Debug("Mock AI request", "videoTitle", title, "promptTemplate", promptTemplate, "transcript", transcript)
+		mac.requestLogger.Debug("Mock AI response", "videoTitle", title, "summary", summary)
+	}
+	return summary, nil
+}
+
+// SetRequestLogger enables logging of prompts and responses at debug level
+func (mac *MockAIClient) SetRequestLogger(logger types.Logger) {
+	mac.requestLogger = logger
+}
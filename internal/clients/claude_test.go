@@ -0,0 +1,93 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"youtube-summarizer/internal/config"
+)
+
+// nopLogger discards all log output
+type nopLogger struct{}
+
+func (nopLogger) Info(msg string, fields ...interface{})             {}
+func (nopLogger) Error(msg string, err error, fields ...interface{}) {}
+func (nopLogger) Debug(msg string, fields ...interface{})            {}
+func (nopLogger) Warn(msg string, fields ...interface{})             {}
+
+// newTestClaudeClient returns a Claude client that sends its requests to server
+func newTestClaudeClient(server *httptest.Server) *ClaudeClient {
+	cc := NewClaudeClient("test-key", NewHTTPClient(0, "", RetryPolicy{}), nopLogger{})
+	cc.baseURL = server.URL
+	return cc
+}
+
+func TestConfiguredMaxTokensReachesRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("ai:\n  max_tokens: 2000\n  temperature: 0.5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.NewLoader(path, "").Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var request ClaudeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Write([]byte(`{"content":[{"type":"text","text":"summary"}]}`))
+	}))
+	defer server.Close()
+
+	cc := newTestClaudeClient(server)
+	cc.SetSampling(cfg.AI.MaxTokens, cfg.AI.Temperature)
+	if _, err := cc.Summarize(context.Background(), "transcript", "title"); err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+
+	if request.MaxTokens != 2000 {
+		t.Errorf("request max_tokens = %d, want 2000", request.MaxTokens)
+	}
+	if request.Temperature != 0.5 {
+		t.Errorf("request temperature = %g, want 0.5", request.Temperature)
+	}
+}
+
+func TestResponseTextSkipsNonTextBlocks(t *testing.T) {
+	got, err := responseText([]ClaudeContent{
+		{Type: "tool_use"},
+		{Type: "text", Text: "  The summary.  "},
+	})
+	if err != nil {
+		t.Fatalf("responseText() error = %v", err)
+	}
+	if got != "The summary." {
+		t.Errorf("responseText() = %q, want %q", got, "The summary.")
+	}
+
+	if _, err := responseText([]ClaudeContent{{Type: "tool_use"}, {Type: "text", Text: " "}}); err == nil {
+		t.Error("responseText() without text succeeded, want an error")
+	}
+}
+
+func TestSummarizeWithLeadingNonTextBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"content":[{"type":"thinking","text":""},{"type":"text","text":"The summary."}]}`))
+	}))
+	defer server.Close()
+
+	summary, err := newTestClaudeClient(server).Summarize(context.Background(), "transcript", "title")
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if summary != "The summary." {
+		t.Errorf("Summarize() = %q, want %q", summary, "The summary.")
+	}
+}
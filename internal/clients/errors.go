@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// statusError describes a non-200 response, wrapping the matching sentinel error if any
+func statusError(api string, statusCode int, detail string) error {
+	msg := fmt.Sprintf("%s returned status %d", api, statusCode)
+	if detail != "" {
+		msg += ": " + detail
+	}
+
+	var sentinel error
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		sentinel = types.ErrAuth
+	case http.StatusTooManyRequests:
+		sentinel = types.ErrRateLimited
+	}
+	if statusCode >= http.StatusInternalServerError {
+		sentinel = types.ErrServerError
+	}
+
+	if sentinel == nil {
+		return errors.New(msg)
+	}
+	return fmt.Errorf("%s: %w", msg, sentinel)
+}
+
+// youtubeStatusError classifies a YouTube Data API error response. YouTube reports
+// exhausted quota as 403 with a reason, so the body is needed to tell it from bad keys.
+func youtubeStatusError(resp *http.Response) error {
+	var apiError struct {
+		Error struct {
+			Message string `json:"message"`
+			Errors  []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = json.Unmarshal(body, &apiError)
+
+	for _, e := range apiError.Error.Errors {
+		switch e.Reason {
+		case "quotaExceeded", "dailyLimitExceeded":
+			return fmt.Errorf("YouTube API returned status %d: %s: %w", resp.StatusCode, apiError.Error.Message, types.ErrQuotaExceeded)
+		case "rateLimitExceeded", "userRateLimitExceeded":
+			return fmt.Errorf("YouTube API returned status %d: %s: %w", resp.StatusCode, apiError.Error.Message, types.ErrRateLimited)
+		}
+	}
+
+	// A rejected API key comes back as 400 keyInvalid rather than 401
+	if resp.StatusCode == http.StatusBadRequest {
+		for _, e := range apiError.Error.Errors {
+			if e.Reason == "keyInvalid" {
+				return fmt.Errorf("YouTube API returned status %d: %s: %w", resp.StatusCode, apiError.Error.Message, types.ErrAuth)
+			}
+		}
+	}
+
+	return statusError("YouTube API", resp.StatusCode, apiError.Error.Message)
+}
+
+// claudeStatusError classifies a Claude API error response by status and error type
+func claudeStatusError(statusCode int, apiError ClaudeError) error {
+	msg := fmt.Sprintf("claude API error (%d)", statusCode)
+	if apiError.Error.Message != "" {
+		msg += ": " + apiError.Error.Message
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return fmt.Errorf("%s: %w", msg, types.ErrAuth)
+	case statusCode == http.StatusTooManyRequests || apiError.Error.Type == "overloaded_error":
+		return fmt.Errorf("%s: %w", msg, types.ErrRateLimited)
+	case strings.Contains(strings.ToLower(apiError.Error.Message), "credit balance"):
+		return fmt.Errorf("%s: %w", msg, types.ErrQuotaExceeded)
+	case statusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("%s: %w", msg, types.ErrServerError)
+	}
+	return errors.New(msg)
+}
+
+// openAIStatusError classifies an OpenAI API error response. OpenAI reports
+// exhausted credit as 429 insufficient_quota, which isn't worth retrying.
+func openAIStatusError(statusCode int, apiError OpenAIError) error {
+	if apiError.Error.Code == "insufficient_quota" {
+		return fmt.Errorf("OpenAI API returned status %d: %s: %w", statusCode, apiError.Error.Message, types.ErrQuotaExceeded)
+	}
+	return statusError("OpenAI API", statusCode, apiError.Error.Message)
+}
+
+// requestError wraps transport errors, marking timeouts with types.ErrTimeout
+func requestError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", types.ErrTimeout, err)
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// FallbackAIClient implements the types.AIClient interface by trying each
+// provider in order until one produces a summary
+type FallbackAIClient struct {
+	providers []types.NamedAIClient
+	logger    types.Logger
+}
+
+// NewFallbackAIClient creates an AI client that falls back through providers in order
+func NewFallbackAIClient(logger types.Logger, providers ...types.NamedAIClient) *FallbackAIClient {
+	return &FallbackAIClient{
+		providers: providers,
+		logger:    logger,
+	}
+}
+
+// Summarize generates a summary with the first provider that succeeds
+func (fc *FallbackAIClient) Summarize(ctx context.Context, transcript, title string) (string, error) {
+	return fc.SummarizeWithPrompt(ctx, "", transcript, title)
+}
+
+// SummarizeWithPrompt generates a summary with the first provider that succeeds
+func (fc *FallbackAIClient) SummarizeWithPrompt(ctx context.Context, promptTemplate, transcript, title string) (string, error) {
+	summary, _, err := fc.SummarizeWithProvider(ctx, promptTemplate, transcript, title, nil)
+	return summary, err
+}
+
+// SummarizeWithProvider tries each provider in order, preceding the prompt with any
+// few-shot examples, and also returns the name of the provider that produced the summary
+func (fc *FallbackAIClient) SummarizeWithProvider(ctx context.Context, promptTemplate, transcript, title string, examples []types.AIExample) (string, string, error) {
+	var errs []error
+	for _, provider := range fc.providers {
+		summary, err := provider.SummarizeWithExamples(ctx, promptTemplate, transcript, title, examples)
+		if err == nil {
+			if len(errs) > 0 {
+				fc.logger.Info("Fallback AI provider succeeded", "provider", provider.Name(), "videoTitle", title)
+			}
+			return summary, provider.Name(), nil
+		}
+
+		// A cancelled run shouldn't burn through the remaining providers
+		if ctx.Err() != nil {
+			return "", "", ctx.Err()
+		}
+
+		fc.logger.Warn("AI provider failed, trying next", "provider", provider.Name(), "videoTitle", title, "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+	}
+
+	if len(errs) == 0 {
+		return "", "", errors.New("no AI providers configured")
+	}
+
+	// Only the last provider's error is wrapped, so errors.Is classifies the failure
+	// the chain ended on rather than matching whatever an earlier provider hit
+	last := errs[len(errs)-1]
+	if len(errs) == 1 {
+		return "", "", fmt.Errorf("all AI providers failed: %w", last)
+	}
+	return "", "", fmt.Errorf("all AI providers failed: %v\n%w", errors.Join(errs[:len(errs)-1]...), last)
+}
+
+// ProviderModel returns the model of the named provider (empty if it isn't in the chain)
+func (fc *FallbackAIClient) ProviderModel(provider string) string {
+	for _, p := range fc.providers {
+		if p.Name() == provider {
+			return p.GetModel()
+		}
+	}
+	return ""
+}
+
+// APICalls returns the request counts of every provider in the chain
+func (fc *FallbackAIClient) APICalls() []types.APICallCount {
+	var counts []types.APICallCount
+	for _, provider := range fc.providers {
+		if counter, ok := provider.(types.CallCounter); ok {
+			counts = append(counts, counter.APICalls()...)
+		}
+	}
+	return counts
+}
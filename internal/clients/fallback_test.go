@@ -0,0 +1,45 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// failingAIClient is a named AI client whose every call fails with err
+type failingAIClient struct {
+	*MockAIClient
+	name string
+	err  error
+}
+
+func (c *failingAIClient) Name() string     { return c.name }
+func (c *failingAIClient) GetModel() string { return c.name + "-model" }
+
+func (c *failingAIClient) SummarizeWithExamples(ctx context.Context, promptTemplate, transcript, title string, examples []types.AIExample) (string, error) {
+	return "", c.err
+}
+
+func TestFallbackClassifiesOnLastProvider(t *testing.T) {
+	fc := NewFallbackAIClient(nopLogger{},
+		&failingAIClient{MockAIClient: NewMockAIClient(nopLogger{}), name: "claude", err: fmt.Errorf("rate limited: %w", types.ErrRateLimited)},
+		&failingAIClient{MockAIClient: NewMockAIClient(nopLogger{}), name: "openai", err: fmt.Errorf("bad key: %w", types.ErrAuth)},
+	)
+
+	_, _, err := fc.SummarizeWithProvider(context.Background(), "", "transcript", "title", nil)
+	if !errors.Is(err, types.ErrAuth) {
+		t.Errorf("error = %v, want it to wrap the last provider's types.ErrAuth", err)
+	}
+	if errors.Is(err, types.ErrRateLimited) {
+		t.Errorf("error = %v matches an earlier provider's types.ErrRateLimited", err)
+	}
+	for _, provider := range []string{"claude", "openai"} {
+		if !strings.Contains(err.Error(), provider) {
+			t.Errorf("error %q doesn't mention %s", err, provider)
+		}
+	}
+}
@@ -2,21 +2,54 @@ package clients
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
+
+	"youtube-summarizer/pkg/types"
 )
 
 // HTTPClient provides a configured HTTP client with timeouts and retries
 type HTTPClient struct {
 	client *http.Client
+	retry  RetryPolicy
+}
+
+// RetryPolicy controls how requests are retried after a 429, 500, 502, 503 or 504
+// response or a network error. MaxAttempts of 1 or less sends each request once.
+type RetryPolicy struct {
+	MaxAttempts int
+	// BaseDelay doubles after each attempt, up to MaxDelay, with random jitter
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// NoRetries sends each request once, for clients that retry failed requests themselves
+var NoRetries = RetryPolicy{MaxAttempts: 1}
+
+// RetryPolicyFromConfig returns the retry policy set in the http config section
+func RetryPolicyFromConfig(config types.HTTPConfig) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: config.RetryAttempts,
+		BaseDelay:   config.RetryBaseDelay,
+		MaxDelay:    config.RetryMaxDelay,
+	}
 }
 
-// NewHTTPClient creates a new HTTP client with sensible defaults
-func NewHTTPClient(timeout time.Duration) *HTTPClient {
+// NewHTTPClient creates a new HTTP client with sensible defaults. Requests go
+// through proxy when set, otherwise through the HTTPS_PROXY/HTTP_PROXY environment,
+// and are retried according to retry.
+func NewHTTPClient(timeout time.Duration, proxy string, retry RetryPolicy) *HTTPClient {
 	return &HTTPClient{
+		retry: retry,
 		client: &http.Client{
 			Timeout: timeout,
 			Transport: &http.Transport{
+				Proxy:               proxyFunc(proxy),
 				MaxIdleConns:        100,
 				MaxIdleConnsPerHost: 10,
 				IdleConnTimeout:     90 * time.Second,
@@ -26,15 +59,27 @@ func NewHTTPClient(timeout time.Duration) *HTTPClient {
 	}
 }
 
+// proxyFunc returns the transport proxy for a configured proxy URL (validated by config)
+func proxyFunc(proxy string) func(*http.Request) (*url.URL, error) {
+	if proxy == "" {
+		return http.ProxyFromEnvironment
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return http.ProxyFromEnvironment
+	}
+	return http.ProxyURL(proxyURL)
+}
+
 // Do executes an HTTP request with context
 func (hc *HTTPClient) Do(req *http.Request) (*http.Response, error) {
-	return hc.client.Do(req)
+	return hc.do(req)
 }
 
 // DoWithContext executes an HTTP request with the provided context
 func (hc *HTTPClient) DoWithContext(ctx context.Context, req *http.Request) (*http.Response, error) {
 	req = req.WithContext(ctx)
-	return hc.client.Do(req)
+	return hc.do(req)
 }
 
 // Get performs a GET request with context
@@ -43,7 +88,7 @@ func (hc *HTTPClient) Get(ctx context.Context, url string) (*http.Response, erro
 	if err != nil {
 		return nil, err
 	}
-	return hc.client.Do(req)
+	return hc.do(req)
 }
 
 // Post performs a POST request with context
@@ -53,5 +98,114 @@ func (hc *HTTPClient) Post(ctx context.Context, url, contentType string, body in
 		return nil, err
 	}
 	req.Header.Set("Content-Type", contentType)
-	return hc.client.Do(req)
+	return hc.do(req)
+}
+
+// do sends the request, marking timeouts with types.ErrTimeout. Requests that are
+// idempotent or have a rewindable body are retried on a retryable status or
+// network error, waiting between attempts unless the request context ends.
+func (hc *HTTPClient) do(req *http.Request) (*http.Response, error) {
+	attempts := hc.retry.MaxAttempts
+	if attempts < 1 || !canRetry(req) {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		// The last response is returned even with a retryable status, so callers
+		// can classify it and read the API's error message from its body
+		resp, err := hc.client.Do(attemptReq)
+		if err == nil && (!retryableStatus(resp.StatusCode) || attempt >= attempts) {
+			return resp, nil
+		}
+		if err != nil && req.Context().Err() != nil {
+			return nil, requestError(err)
+		}
+		if attempt >= attempts {
+			if attempts > 1 {
+				return nil, fmt.Errorf("request failed after %d attempts: %w", attempts, requestError(err))
+			}
+			return nil, requestError(err)
+		}
+
+		delay := hc.retry.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok && after > delay {
+				delay = min(after, hc.retry.MaxDelay)
+			}
+			drainBody(resp)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, requestError(req.Context().Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// canRetry reports whether a request can safely be sent again: an idempotent
+// request without a body, or any request whose body can be recreated
+func canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody {
+		return req.GetBody != nil
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.GetBody != nil
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait after the given attempt: BaseDelay doubled per attempt,
+// capped at MaxDelay, with jitter in its upper half so clients don't retry in step
+func (rp RetryPolicy) backoff(attempt int) time.Duration {
+	delay := rp.BaseDelay
+	for i := 1; i < attempt && (rp.MaxDelay <= 0 || delay < rp.MaxDelay); i++ {
+		delay *= 2
+	}
+	if rp.MaxDelay > 0 && delay > rp.MaxDelay {
+		delay = rp.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryAfter reads a Retry-After header given in seconds
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// drainBody reads and closes a response body that won't be returned, so the
+// connection can be reused
+func drainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
 }
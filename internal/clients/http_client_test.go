@@ -0,0 +1,37 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExhaustedRetriesReturnLastResponse(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"backend overloaded"}`))
+	}))
+	defer server.Close()
+
+	hc := NewHTTPClient(0, "", RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	resp, err := hc.Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if calls != 3 {
+		t.Errorf("server got %d requests, want 3", calls)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != `{"error":"backend overloaded"}` {
+		t.Errorf("body = %q, want the API error", body)
+	}
+}
@@ -0,0 +1,180 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// Notion limits each rich text object to 2000 characters
+const notionTextLimit = 2000
+
+// NotionClient exports summaries as pages in a Notion database.
+// The database must have the properties Name (title), Channel (text),
+// URL (url), and Published (date).
+type NotionClient struct {
+	httpClient *HTTPClient
+	token      string
+	databaseID string
+	baseURL    string
+	maxRetries int
+	logger     types.Logger
+}
+
+// NewNotionClient creates a new Notion API client
+func NewNotionClient(token, databaseID string, httpClient *HTTPClient, logger types.Logger) *NotionClient {
+	return &NotionClient{
+		httpClient: httpClient,
+		token:      token,
+		databaseID: databaseID,
+		baseURL:    "https://api.notion.com/v1",
+		maxRetries: 5,
+		logger:     logger,
+	}
+}
+
+// NotionQueryResponse represents the database query response
+type NotionQueryResponse struct {
+	Results []struct {
+		ID string `json:"id"`
+	} `json:"results"`
+}
+
+// NotionError represents an error response from the Notion API
+type NotionError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PageExists reports whether the database already has a page for the video URL
+func (nc *NotionClient) PageExists(ctx context.Context, videoURL string) (bool, error) {
+	query := map[string]interface{}{
+		"filter": map[string]interface{}{
+			"property": "URL",
+			"url":      map[string]string{"equals": videoURL},
+		},
+		"page_size": 1,
+	}
+
+	var response NotionQueryResponse
+	if err := nc.do(ctx, "POST", fmt.Sprintf("/databases/%s/query", nc.databaseID), query, &response); err != nil {
+		return false, err
+	}
+
+	return len(response.Results) > 0, nil
+}
+
+// CreateSummaryPage creates a database page for the summary with the summary text as the body
+func (nc *NotionClient) CreateSummaryPage(ctx context.Context, summary types.Summary) error {
+	properties := map[string]interface{}{
+		"Name":    map[string]interface{}{"title": notionRichText(summary.VideoTitle)},
+		"Channel": map[string]interface{}{"rich_text": notionRichText(summary.ChannelName)},
+		"URL":     map[string]interface{}{"url": summary.VideoURL},
+	}
+	if !summary.PublishedAt.IsZero() {
+		properties["Published"] = map[string]interface{}{
+			"date": map[string]string{"start": summary.PublishedAt.Format("2006-01-02")},
+		}
+	}
+
+	page := map[string]interface{}{
+		"parent":     map[string]string{"database_id": nc.databaseID},
+		"properties": properties,
+		"children": []interface{}{
+			map[string]interface{}{
+				"object":    "block",
+				"type":      "paragraph",
+				"paragraph": map[string]interface{}{"rich_text": notionRichText(summary.Summary)},
+			},
+		},
+	}
+
+	if err := nc.do(ctx, "POST", "/pages", page, nil); err != nil {
+		return err
+	}
+
+	nc.logger.Debug("Created Notion page", "videoID", summary.VideoID, "title", summary.VideoTitle)
+	return nil
+}
+
+// do sends a request to the Notion API, backing off and retrying when rate limited
+func (nc *NotionClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Notion request: %w", err)
+	}
+
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, nc.baseURL+path, bytes.NewReader(requestBody))
+		if err != nil {
+			return fmt.Errorf("failed to create Notion request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+nc.token)
+		req.Header.Set("Notion-Version", "2022-06-28")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := nc.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to call Notion API: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < nc.maxRetries {
+			wait := backoff
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+				wait = time.Duration(seconds) * time.Second
+			}
+			resp.Body.Close()
+
+			nc.logger.Warn("Notion rate limit hit, backing off", "wait", wait.String(), "attempt", attempt)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			backoff *= 2
+			continue
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			var notionError NotionError
+			if err := json.NewDecoder(resp.Body).Decode(&notionError); err == nil && notionError.Message != "" {
+				return fmt.Errorf("notion API error (%d): %s", resp.StatusCode, notionError.Message)
+			}
+			return fmt.Errorf("notion API returned status %d", resp.StatusCode)
+		}
+
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return fmt.Errorf("failed to decode Notion API response: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+// notionRichText splits text into rich text objects within Notion's length limit
+func notionRichText(text string) []interface{} {
+	runes := []rune(text)
+	var parts []interface{}
+	for len(runes) > 0 {
+		n := len(runes)
+		if n > notionTextLimit {
+			n = notionTextLimit
+		}
+		parts = append(parts, map[string]interface{}{
+			"type": "text",
+			"text": map[string]string{"content": string(runes[:n])},
+		})
+		runes = runes[n:]
+	}
+	return parts
+}
@@ -0,0 +1,214 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// OpenAIClient implements the types.AIClient interface using the OpenAI chat completions API
+type OpenAIClient struct {
+	httpClient *HTTPClient
+	apiKey     string
+	baseURL    string
+	model      string
+	logger     types.Logger
+	// requestLogger, when set, receives full prompts and raw responses (ai.log_requests)
+	requestLogger types.Logger
+	calls         atomic.Int64 // Chat completions requests made
+	tokens        atomic.Int64 // input plus output tokens used
+	// promptTemplate is used when a call passes no template (ai.summary_prompt)
+	promptTemplate string
+}
+
+// NewOpenAIClient creates a new OpenAI API client
+func NewOpenAIClient(apiKey string, httpClient *HTTPClient, logger types.Logger) *OpenAIClient {
+	return &OpenAIClient{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+		baseURL:    "https://api.openai.com/v1",
+		model:      "gpt-4o-mini",
+		logger:     logger,
+	}
+}
+
+// OpenAIRequest represents the request structure for the chat completions API
+type OpenAIRequest struct {
+	Model     string          `json:"model"`
+	MaxTokens int             `json:"max_tokens"`
+	Messages  []OpenAIMessage `json:"messages"`
+}
+
+// OpenAIMessage represents a message in the conversation
+type OpenAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// OpenAIResponse represents the response from the chat completions API
+type OpenAIResponse struct {
+	Choices []OpenAIChoice `json:"choices"`
+	Usage   OpenAIUsage    `json:"usage"`
+}
+
+// OpenAIChoice represents a completion choice
+type OpenAIChoice struct {
+	Message OpenAIMessage `json:"message"`
+}
+
+// OpenAIUsage represents token usage information
+type OpenAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// OpenAIError represents an error response from the OpenAI API
+type OpenAIError struct {
+	Error struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+	} `json:"error"`
+}
+
+// Summarize generates a summary of the video transcript using OpenAI
+func (oc *OpenAIClient) Summarize(ctx context.Context, transcript, title string) (string, error) {
+	return oc.SummarizeWithPrompt(ctx, "", transcript, title)
+}
+
+// SummarizeWithPrompt generates a summary using the given prompt template.
+// The template may contain {title} and {transcript} placeholders; an empty
+// template uses the built-in default prompt.
+func (oc *OpenAIClient) SummarizeWithPrompt(ctx context.Context, promptTemplate, transcript, title string) (string, error) {
+	return oc.SummarizeWithExamples(ctx, promptTemplate, transcript, title, nil)
+}
+
+// SummarizeWithExamples is SummarizeWithPrompt preceded by few-shot examples as
+// prior user/assistant turns
+func (oc *OpenAIClient) SummarizeWithExamples(ctx context.Context, promptTemplate, transcript, title string, examples []types.AIExample) (string, error) {
+	// Truncate transcript if it's too long
+	maxLength := 50000
+	if len(transcript) > maxLength {
+		transcript = transcript[:maxLength] + "... [transcript truncated]"
+		oc.logger.Debug("Truncated long transcript", "originalLength", len(transcript), "maxLength", maxLength)
+	}
+
+	if promptTemplate == "" {
+		promptTemplate = oc.promptTemplate
+	}
+	prompt := buildPrompt(promptTemplate, transcript, title)
+
+	var messages []OpenAIMessage
+	for _, example := range examples {
+		messages = append(messages,
+			OpenAIMessage{Role: "user", Content: buildPrompt(promptTemplate, example.Input, example.Title)},
+			OpenAIMessage{Role: "assistant", Content: example.Output})
+	}
+	messages = append(messages, OpenAIMessage{Role: "user", Content: prompt})
+
+	requestBody, err := json.Marshal(OpenAIRequest{
+		Model:     oc.model,
+		MaxTokens: 1000,
+		Messages:  messages,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal OpenAI request: %w", err)
+	}
+
+	oc.logger.Debug("Sending request to OpenAI API", "videoTitle", title, "transcriptLength", len(transcript))
+	if oc.requestLogger != nil {
+		oc.requestLogger.Debug("OpenAI API request", "videoTitle", title, "model", oc.model, "examples", len(examples), "prompt", prompt)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", oc.baseURL+"/chat/completions", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create OpenAI API request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+oc.apiKey)
+
+	oc.calls.Add(1)
+	resp, err := oc.httpClient.DoWithContext(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call OpenAI API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OpenAI API response: %w", err)
+	}
+	if oc.requestLogger != nil {
+		oc.requestLogger.Debug("OpenAI API response", "videoTitle", title, "status", resp.StatusCode, "body", string(body))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiError OpenAIError
+		_ = json.Unmarshal(body, &apiError)
+		return "", openAIStatusError(resp.StatusCode, apiError)
+	}
+
+	var openAIResponse OpenAIResponse
+	if err := json.Unmarshal(body, &openAIResponse); err != nil {
+		return "", fmt.Errorf("failed to decode OpenAI API response: %w", err)
+	}
+
+	if len(openAIResponse.Choices) == 0 {
+		return "", errors.New("OpenAI API returned no choices")
+	}
+
+	summary := strings.TrimSpace(openAIResponse.Choices[0].Message.Content)
+	if summary == "" {
+		return "", errors.New("OpenAI API returned empty summary")
+	}
+
+	oc.tokens.Add(int64(openAIResponse.Usage.PromptTokens + openAIResponse.Usage.CompletionTokens))
+
+	oc.logger.Info("Generated summary using OpenAI",
+		"videoTitle", title,
+		"inputTokens", openAIResponse.Usage.PromptTokens,
+		"outputTokens", openAIResponse.Usage.CompletionTokens,
+		"summaryLength", len(summary))
+
+	return summary, nil
+}
+
+// SetModel allows changing the OpenAI model used for summarization
+func (oc *OpenAIClient) SetModel(model string) {
+	oc.model = model
+	oc.logger.Debug("Changed OpenAI model", "model", model)
+}
+
+// SetPromptTemplate sets the template used when a call passes none; {title} and
+// {transcript} are filled in, and an empty template restores the built-in prompt
+func (oc *OpenAIClient) SetPromptTemplate(promptTemplate string) {
+	oc.promptTemplate = promptTemplate
+}
+
+// SetRequestLogger enables logging of full prompts and raw responses at debug level
+func (oc *OpenAIClient) SetRequestLogger(logger types.Logger) {
+	oc.requestLogger = logger
+}
+
+// GetModel returns the current OpenAI model being used
+func (oc *OpenAIClient) GetModel() string {
+	return oc.model
+}
+
+// Name returns the provider name used in ai.providers
+func (oc *OpenAIClient) Name() string {
+	return "openai"
+}
+
+// APICalls returns the number of OpenAI API requests made so far and the tokens they used
+func (oc *OpenAIClient) APICalls() []types.APICallCount {
+	return []types.APICallCount{{API: "OpenAI", Calls: oc.calls.Load(), Tokens: oc.tokens.Load()}}
+}
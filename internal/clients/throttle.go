@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// rateLimitThrottle pauses requests when an API's rate-limit headers show the
+// remaining budget is nearly spent, until the budget resets, instead of running
+// into 429 responses. A zero threshold disables that check.
+type rateLimitThrottle struct {
+	minRequests int
+	minTokens   int
+	logger      types.Logger
+
+	mu         sync.Mutex
+	pauseUntil time.Time
+}
+
+// wait blocks until any pause from an earlier response has passed
+func (t *rateLimitThrottle) wait(ctx context.Context) error {
+	t.mu.Lock()
+	delay := time.Until(t.pauseUntil)
+	t.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	t.logger.Info("Rate limit budget low, pausing AI requests", "delay", delay.Round(time.Second).String())
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// observeAnthropic reads the anthropic-ratelimit-* headers of a response and
+// schedules a pause until the reset time when requests or tokens run low
+func (t *rateLimitThrottle) observeAnthropic(header http.Header) {
+	requests, requestsOK := headerInt(header, "anthropic-ratelimit-requests-remaining")
+	tokens, tokensOK := headerInt(header, "anthropic-ratelimit-tokens-remaining")
+	if !requestsOK && !tokensOK {
+		return
+	}
+	t.logger.Debug("Claude rate limit budget", "requestsRemaining", requests, "tokensRemaining", tokens)
+
+	if requestsOK && t.minRequests > 0 && requests < t.minRequests {
+		t.pauseUntilReset(header.Get("anthropic-ratelimit-requests-reset"))
+	}
+	if tokensOK && t.minTokens > 0 && tokens < t.minTokens {
+		t.pauseUntilReset(header.Get("anthropic-ratelimit-tokens-reset"))
+	}
+}
+
+// pauseUntilReset extends the pause to an RFC 3339 reset time
+func (t *rateLimitThrottle) pauseUntilReset(reset string) {
+	resetAt, err := time.Parse(time.RFC3339, reset)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if resetAt.After(t.pauseUntil) {
+		t.pauseUntil = resetAt
+	}
+}
+
+// headerInt parses an integer header, reporting whether it was present and valid
+func headerInt(header http.Header, name string) (int, bool) {
+	value, err := strconv.Atoi(header.Get(name))
+	return value, err == nil
+}
@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// videoIDPattern guards cache paths against traversal via crafted IDs
+var videoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ThumbnailCache implements the types.ThumbnailCache interface by storing
+// thumbnails on disk so the UI doesn't depend on hotlinking YouTube images
+type ThumbnailCache struct {
+	httpClient *HTTPClient
+	dir        string
+	logger     types.Logger
+}
+
+// NewThumbnailCache creates a thumbnail cache rooted at dir
+func NewThumbnailCache(dir string, httpClient *HTTPClient, logger types.Logger) *ThumbnailCache {
+	return &ThumbnailCache{
+		httpClient: httpClient,
+		dir:        dir,
+		logger:     logger,
+	}
+}
+
+// Fetch downloads the thumbnail for a video unless it is already cached and returns its path
+func (tc *ThumbnailCache) Fetch(ctx context.Context, videoID, thumbnailURL string) (string, error) {
+	path, err := tc.Path(videoID)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(tc.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create thumbnail directory: %w", err)
+	}
+
+	resp, err := tc.httpClient.Get(ctx, thumbnailURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to download thumbnail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("thumbnail download returned status %d", resp.StatusCode)
+	}
+
+	// Write to a temp file first so a failed download never leaves a partial image
+	tmp, err := os.CreateTemp(tc.dir, videoID+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create thumbnail file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write thumbnail: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write thumbnail: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	tc.logger.Debug("Cached thumbnail", "videoID", videoID, "path", path)
+	return path, nil
+}
+
+// Path returns the cache location for a video's thumbnail
+func (tc *ThumbnailCache) Path(videoID string) (string, error) {
+	if !videoIDPattern.MatchString(videoID) {
+		return "", fmt.Errorf("invalid video ID: %q", videoID)
+	}
+	return filepath.Join(tc.dir, videoID+".jpg"), nil
+}
+
+// ServeHTTP serves cached thumbnails for GET /thumb/{videoID}
+func (tc *ThumbnailCache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, err := tc.Path(r.PathValue("videoID"))
+	if err != nil {
+		http.Error(w, "invalid video ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeFile(w, r, path)
+}
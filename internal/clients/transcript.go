@@ -6,27 +6,56 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
-	"time"
+	"sync/atomic"
 
 	"youtube-summarizer/pkg/types"
 )
 
+// Default RapidAPI transcript provider
+const (
+	DefaultTranscriptHost    = "youtube-transcriptor.p.rapidapi.com"
+	DefaultTranscriptBaseURL = "https://youtube-transcriptor.p.rapidapi.com"
+)
+
 // TranscriptClient implements the types.TranscriptClient interface
 type TranscriptClient struct {
 	httpClient  *HTTPClient
 	rapidAPIKey string
 	baseURL     string
+	host        string
 	logger      types.Logger
+	calls       atomic.Int64 // RapidAPI requests made, for the run summary
+	// languages are the preferred transcript languages in order; "auto" accepts the
+	// provider's default
+	languages []string
 }
 
-// NewTranscriptClient creates a new transcript client using RapidAPI
-func NewTranscriptClient(rapidAPIKey string, logger types.Logger) *TranscriptClient {
+// NewTranscriptClient creates a new transcript client using RapidAPI.
+// Empty baseURL or host values fall back to the default provider.
+func NewTranscriptClient(rapidAPIKey, baseURL, host string, httpClient *HTTPClient, logger types.Logger) *TranscriptClient {
+	if baseURL == "" {
+		baseURL = DefaultTranscriptBaseURL
+	}
+	if host == "" {
+		host = DefaultTranscriptHost
+	}
+
 	return &TranscriptClient{
-		httpClient:  NewHTTPClient(45 * time.Second), // Longer timeout for transcript fetching
+		httpClient:  httpClient,
 		rapidAPIKey: rapidAPIKey,
-		baseURL:     "https://youtube-transcriptor.p.rapidapi.com",
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		host:        host,
 		logger:      logger,
+		languages:   []string{transcriptLang},
+	}
+}
+
+// SetLanguages sets the preferred transcript languages, tried in order
+func (tc *TranscriptClient) SetLanguages(languages []string) {
+	if len(languages) > 0 {
+		tc.languages = languages
 	}
 }
 
@@ -54,20 +83,6 @@ type TranscriptEntry struct {
 	Dur      float64 `json:"dur"`
 }
 
-// Alternative transcript client for fallback
-type AlternativeTranscriptClient struct {
-	httpClient *HTTPClient
-	logger     types.Logger
-}
-
-// NewAlternativeTranscriptClient creates a fallback transcript client
-func NewAlternativeTranscriptClient(logger types.Logger) *AlternativeTranscriptClient {
-	return &AlternativeTranscriptClient{
-		httpClient: NewHTTPClient(30 * time.Second),
-		logger:     logger,
-	}
-}
-
 // GetTranscript fetches the transcript for a YouTube video
 func (tc *TranscriptClient) GetTranscript(ctx context.Context, videoID string) (string, error) {
 	data, err := tc.GetTranscriptWithThumbnail(ctx, videoID)
@@ -85,65 +100,44 @@ func (tc *TranscriptClient) GetTranscriptWithThumbnail(ctx context.Context, vide
 		tc.logger.Warn("RapidAPI transcript failed, trying alternative", "videoID", videoID, "error", err)
 
 		// Fallback to alternative method
-		altClient := NewAlternativeTranscriptClient(tc.logger)
-		return altClient.getAlternativeTranscriptWithThumbnail(ctx, videoID)
+		altClient := NewAlternativeTranscriptClient(tc.httpClient, tc.logger)
+		altClient.SetLanguages(tc.languages)
+		data, err := altClient.getAlternativeTranscriptWithThumbnail(ctx, videoID)
+		if err != nil {
+			return nil, err
+		}
+		data.Source = types.SourceAltTranscript
+		return data, nil
 	}
 
 	return data, nil
 }
 
-// getRapidAPITranscriptWithThumbnail uses RapidAPI to fetch transcript and thumbnail
+// getRapidAPITranscriptWithThumbnail uses RapidAPI to fetch transcript and thumbnail.
+// The first preferred language is requested; when the video doesn't have it, the
+// response's available languages decide whether a later preferred one is fetched.
 func (tc *TranscriptClient) getRapidAPITranscriptWithThumbnail(ctx context.Context, videoID string) (*types.TranscriptData, error) {
-	// Build the URL exactly like the RapidAPI example
-	url := fmt.Sprintf("https://youtube-transcriptor.p.rapidapi.com/transcript?video_id=%s&lang=en", videoID)
-
-	tc.logger.Debug("Fetching transcript from RapidAPI", "videoID", videoID)
-
-	// Create request exactly like the RapidAPI example
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create transcript request: %w", err)
+	requested := tc.languages[0]
+	if requested == autoTranscriptLang {
+		requested = ""
 	}
-
-	// Set headers exactly like the RapidAPI example
-	req.Header.Add("x-rapidapi-key", tc.rapidAPIKey)
-	req.Header.Add("x-rapidapi-host", "youtube-transcriptor.p.rapidapi.com")
-	req.Header.Add("Accept", "application/json")
-
-	// Make the request
-	res, err := http.DefaultClient.Do(req)
+	response, err := tc.fetchRapidAPITranscript(ctx, videoID, requested)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch transcript: %w", err)
+		return nil, err
 	}
-	defer res.Body.Close()
+	lang := resolveTranscriptLang(requested, response.AvailableLangs)
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("transcript API returned status %d", res.StatusCode)
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Debug: Log the raw response
-	tc.logger.Debug("Raw API response", "videoID", videoID, "body", string(body))
-
-	// Parse the JSON response - it's an array with one object containing transcription
-	var responseArray []TranscriptResponse
-	if err := json.Unmarshal(body, &responseArray); err != nil {
-		// Log the error with the response body for debugging
-		tc.logger.Error("Failed to parse JSON response", err, "videoID", videoID, "responseBody", string(body))
-		return nil, fmt.Errorf("failed to decode transcript response: %w", err)
-	}
-
-	if len(responseArray) == 0 {
-		return nil, fmt.Errorf("empty response array for video %s", videoID)
+	if preferred := pickTranscriptLang(tc.languages, response.AvailableLangs); preferred != "" && !strings.EqualFold(preferred, lang) {
+		tc.logger.Debug("Fetching transcript in a preferred language", "videoID", videoID, "lang", preferred, "available", response.AvailableLangs)
+		response, err = tc.fetchRapidAPITranscript(ctx, videoID, preferred)
+		if err != nil {
+			return nil, err
+		}
+		lang = resolveTranscriptLang(preferred, response.AvailableLangs)
 	}
 
 	// Get the transcript entries from the transcription field
-	transcriptEntries := responseArray[0].Transcription
+	transcriptEntries := response.Transcription
 
 	tc.logger.Debug("Extracted transcript entries", "videoID", videoID, "entryCount", len(transcriptEntries))
 
@@ -158,7 +152,11 @@ func (tc *TranscriptClient) getRapidAPITranscriptWithThumbnail(ctx context.Conte
 
 	transcript := transcriptText.String()
 	if transcript == "" {
-		return nil, fmt.Errorf("empty transcript received for video %s", videoID)
+		// Without any listed language there's nothing to retry; the description is used instead
+		if len(response.AvailableLangs) == 0 {
+			return nil, fmt.Errorf("no transcript languages available for video %s", videoID)
+		}
+		return nil, fmt.Errorf("empty transcript received for video %s: %w", videoID, types.ErrTranscriptUnavailable)
 	}
 
 	// Use reliable YouTube thumbnail URLs that work in email clients
@@ -168,8 +166,8 @@ func (tc *TranscriptClient) getRapidAPITranscriptWithThumbnail(ctx context.Conte
 	tc.logger.Debug("Using standard YouTube thumbnail", "videoID", videoID, "thumbnailURL", thumbnailURL)
 
 	// Alternative: if we want to try API thumbnails, prefer simple JPG URLs without query params
-	if len(responseArray[0].Thumbnails) > 0 {
-		for _, thumb := range responseArray[0].Thumbnails {
+	if len(response.Thumbnails) > 0 {
+		for _, thumb := range response.Thumbnails {
 			// Prefer JPG URLs without complex query parameters for email compatibility
 			if strings.Contains(thumb.URL, ".jpg") && !strings.Contains(thumb.URL, "?") {
 				thumbnailURL = thumb.URL
@@ -183,31 +181,166 @@ func (tc *TranscriptClient) getRapidAPITranscriptWithThumbnail(ctx context.Conte
 		"videoID", videoID,
 		"length", len(transcript),
 		"segments", len(transcriptEntries),
+		"lang", lang,
 		"thumbnailURL", thumbnailURL)
 
 	return &types.TranscriptData{
 		Transcript:   transcript,
 		ThumbnailURL: thumbnailURL,
+		SegmentCount: len(transcriptEntries),
+		Source:       types.SourceTranscript,
+		Language:     lang,
 	}, nil
 }
 
-// getAlternativeTranscriptWithThumbnail uses a fallback method to get transcripts
-func (atc *AlternativeTranscriptClient) getAlternativeTranscriptWithThumbnail(ctx context.Context, videoID string) (*types.TranscriptData, error) {
-	// This is a placeholder for alternative transcript fetching methods
-	// In a real implementation, you might use:
-	// 1. YouTube's official captions API (if available)
-	// 2. Another third-party service
-	// 3. A local transcript extraction tool
+// fetchRapidAPITranscript requests a video's transcript in a language; an empty
+// language leaves the choice to the provider
+func (tc *TranscriptClient) fetchRapidAPITranscript(ctx context.Context, videoID, lang string) (*TranscriptResponse, error) {
+	// Build the URL from the configured provider
+	params := url.Values{}
+	params.Add("video_id", videoID)
+	if lang != "" {
+		params.Add("lang", lang)
+	}
+	requestURL := fmt.Sprintf("%s/transcript?%s", tc.baseURL, params.Encode())
+
+	tc.logger.Debug("Fetching transcript from RapidAPI", "videoID", videoID, "host", tc.host, "lang", lang)
+
+	// Create request exactly like the RapidAPI example
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transcript request: %w", err)
+	}
+
+	// Set headers exactly like the RapidAPI example
+	req.Header.Add("x-rapidapi-key", tc.rapidAPIKey)
+	req.Header.Add("x-rapidapi-host", tc.host)
+	req.Header.Add("Accept", "application/json")
+
+	// Make the request using the configured client so the transcript timeout applies
+	tc.calls.Add(1)
+	res, err := tc.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transcript: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("transcript API returned status %d for video %s: %w", res.StatusCode, videoID, types.ErrTranscriptUnavailable)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, statusError("transcript API", res.StatusCode, "")
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Debug: Log the raw response
+	tc.logger.Debug("Raw API response", "videoID", videoID, "body", string(body))
+
+	response, err := decodeTranscriptResponse(body)
+	if err != nil {
+		// Log the error with the response body for debugging
+		tc.logger.Error("Failed to parse JSON response", err, "videoID", videoID, "responseBody", string(body))
+		return nil, fmt.Errorf("failed to decode transcript response for video %s: %w", videoID, err)
+	}
+
+	return response, nil
+}
+
+// transcriptLang is the transcript language requested when none is configured
+const transcriptLang = "en"
+
+// autoTranscriptLang in transcript.languages accepts the provider's default language
+const autoTranscriptLang = "auto"
 
-	atc.logger.Warn("Alternative transcript method not implemented", "videoID", videoID)
-	return nil, fmt.Errorf("alternative transcript method not available for video %s", videoID)
+// pickTranscriptLang returns the first preferred language the video has, as the
+// provider spells it, or "" to keep the provider's choice: when "auto" comes first
+// or none of the preferred languages is available
+func pickTranscriptLang(preferred, available []string) string {
+	for _, want := range preferred {
+		if want == autoTranscriptLang {
+			return ""
+		}
+		for _, lang := range available {
+			if strings.EqualFold(lang, want) {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// resolveTranscriptLang returns the language a transcript came in: the requested
+// one when the video has it (or the provider doesn't say), otherwise the first
+// available language, which is what the provider falls back to
+func resolveTranscriptLang(requested string, available []string) string {
+	if len(available) == 0 {
+		return requested
+	}
+	for _, lang := range available {
+		if strings.EqualFold(lang, requested) {
+			return requested
+		}
+	}
+	return available[0]
+}
+
+// decodeTranscriptResponse parses a RapidAPI transcript body. The provider normally
+// returns an array with one object, but sometimes a bare object, or an error object
+// such as {"error": "..."} whose message is returned as the error.
+func decodeTranscriptResponse(body []byte) (*TranscriptResponse, error) {
+	var responseArray []TranscriptResponse
+	if err := json.Unmarshal(body, &responseArray); err == nil {
+		if len(responseArray) == 0 {
+			return nil, fmt.Errorf("empty response array: %w", types.ErrTranscriptUnavailable)
+		}
+		return &responseArray[0], nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("response is neither an array nor an object: %w", err)
+	}
+
+	if raw, ok := fields["error"]; ok {
+		return nil, fmt.Errorf("transcript API error: %s", providerErrorMessage(raw))
+	}
+	if _, ok := fields["transcription"]; !ok {
+		if raw, ok := fields["message"]; ok {
+			return nil, fmt.Errorf("transcript API error: %s", providerErrorMessage(raw))
+		}
+	}
+
+	var response TranscriptResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// providerErrorMessage returns a JSON error field as text, unquoting plain strings
+// and reading the message of nested {"message": "..."} objects
+func providerErrorMessage(raw json.RawMessage) string {
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return message
+	}
+	var nested struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &nested); err == nil && nested.Message != "" {
+		return nested.Message
+	}
+	return string(raw)
 }
 
-// YouTube Direct Caption API (placeholder for future implementation)
-func (atc *AlternativeTranscriptClient) getYouTubeCaptions(ctx context.Context, videoID string) (string, error) {
-	// This would use YouTube's caption API if we had access
-	// For now, return an error
-	return "", fmt.Errorf("YouTube direct caption API not implemented")
+// APICalls returns the number of RapidAPI requests made so far
+func (tc *TranscriptClient) APICalls() []types.APICallCount {
+	return []types.APICallCount{{API: "RapidAPI", Calls: tc.calls.Load()}}
 }
 
 // MockTranscriptClient for testing purposes
@@ -246,5 +379,8 @@ func (mtc *MockTranscriptClient) GetTranscriptWithThumbnail(ctx context.Context,
 	return &types.TranscriptData{
 		Transcript:   transcript,
 		ThumbnailURL: thumbnailURL,
+		SegmentCount: 1,
+		Source:       types.SourceTranscript,
+		Language:     transcriptLang,
 	}, nil
 }
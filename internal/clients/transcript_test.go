@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"youtube-summarizer/pkg/types"
+)
+
+func TestDecodeTranscriptResponse(t *testing.T) {
+	const entry = `{"title":"Video","availableLangs":["en"],"transcription":[{"subtitle":"hello","start":0,"dur":1.5}]}`
+
+	for name, body := range map[string]string{
+		"array":       "[" + entry + "]",
+		"bare object": entry,
+	} {
+		response, err := decodeTranscriptResponse([]byte(body))
+		if err != nil {
+			t.Errorf("%s: decodeTranscriptResponse() error = %v", name, err)
+			continue
+		}
+		if response.Title != "Video" || len(response.Transcription) != 1 || response.Transcription[0].Subtitle != "hello" {
+			t.Errorf("%s: decodeTranscriptResponse() = %+v", name, response)
+		}
+	}
+}
+
+func TestDecodeTranscriptResponseErrors(t *testing.T) {
+	if _, err := decodeTranscriptResponse([]byte(`[]`)); !errors.Is(err, types.ErrTranscriptUnavailable) {
+		t.Errorf("empty array: error = %v, want types.ErrTranscriptUnavailable", err)
+	}
+
+	for body, want := range map[string]string{
+		`{"error":"video not found"}`:            "video not found",
+		`{"error":{"message":"quota exceeded"}}`: "quota exceeded",
+		`{"message":"You are not subscribed"}`:   "You are not subscribed",
+	} {
+		_, err := decodeTranscriptResponse([]byte(body))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("decodeTranscriptResponse(%s) error = %v, want it to mention %q", body, err, want)
+		}
+	}
+
+	if _, err := decodeTranscriptResponse([]byte(`"not json object"`)); err == nil {
+		t.Error("decodeTranscriptResponse() of a string succeeded, want an error")
+	}
+}
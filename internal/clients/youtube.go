@@ -3,10 +3,15 @@ package clients
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"youtube-summarizer/pkg/types"
@@ -18,15 +23,21 @@ type YouTubeClient struct {
 	apiKey     string
 	baseURL    string
 	logger     types.Logger
+	// calls counts API requests; the client is shared by the channel goroutines
+	calls atomic.Int64
+
+	resolvedMu sync.Mutex
+	resolved   map[string]string
 }
 
 // NewYouTubeClient creates a new YouTube API client
-func NewYouTubeClient(apiKey string, logger types.Logger) *YouTubeClient {
+func NewYouTubeClient(apiKey string, httpClient *HTTPClient, logger types.Logger) *YouTubeClient {
 	return &YouTubeClient{
-		httpClient: NewHTTPClient(30 * time.Second),
+		httpClient: httpClient,
 		apiKey:     apiKey,
 		baseURL:    "https://www.googleapis.com/youtube/v3",
 		logger:     logger,
+		resolved:   make(map[string]string),
 	}
 }
 
@@ -68,8 +79,374 @@ type YouTubeContentDetails struct {
 	Duration string `json:"duration"`
 }
 
-// GetChannelVideos retrieves recent videos from a YouTube channel
-func (yc *YouTubeClient) GetChannelVideos(ctx context.Context, channelID string, maxResults int) ([]types.Video, error) {
+// YouTubeVideoStatsResponse represents a videos endpoint response for statistics
+// and content details only
+type YouTubeVideoStatsResponse struct {
+	Items []YouTubeVideoStatsItem `json:"items"`
+}
+
+// YouTubeVideoStatsItem represents a video's statistics and content details
+type YouTubeVideoStatsItem struct {
+	ID             string                 `json:"id"`
+	Statistics     YouTubeVideoStatistics `json:"statistics"`
+	ContentDetails YouTubeContentDetails  `json:"contentDetails"`
+}
+
+// YouTubeChannelListResponse represents the channels endpoint response
+type YouTubeChannelListResponse struct {
+	Items []YouTubeChannelItem `json:"items"`
+}
+
+// YouTubeChannelItem represents a channel item from the API
+type YouTubeChannelItem struct {
+	ID             string                       `json:"id"`
+	ContentDetails YouTubeChannelContentDetails `json:"contentDetails"`
+}
+
+// YouTubeChannelContentDetails represents channel content details
+type YouTubeChannelContentDetails struct {
+	RelatedPlaylists struct {
+		Uploads string `json:"uploads"`
+	} `json:"relatedPlaylists"`
+}
+
+// YouTubePlaylistItemsResponse represents the playlistItems endpoint response
+type YouTubePlaylistItemsResponse struct {
+	NextPageToken string                `json:"nextPageToken"`
+	Items         []YouTubePlaylistItem `json:"items"`
+}
+
+// YouTubePlaylistItem represents a single playlist entry
+type YouTubePlaylistItem struct {
+	Snippet        YouTubeVideoSnippet               `json:"snippet"`
+	ContentDetails YouTubePlaylistItemContentDetails `json:"contentDetails"`
+}
+
+// YouTubePlaylistItemContentDetails represents playlist item content details
+type YouTubePlaylistItemContentDetails struct {
+	VideoID          string    `json:"videoId"`
+	VideoPublishedAt time.Time `json:"videoPublishedAt,omitempty"`
+}
+
+// GetChannelVideos retrieves recent videos from a YouTube channel, leaving out those
+// published at or before a non-zero publishedAfter.
+// It reads the channel's uploads playlist first, which is cheaper in quota and
+// more reliable for very recent uploads, and falls back to the search endpoint.
+func (yc *YouTubeClient) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter time.Time) ([]types.Video, error) {
+	videos, err := yc.getUploadsPlaylistVideos(ctx, channelID, maxResults, publishedAfter)
+	if err != nil {
+		// Search would fail the same way (and costs far more quota)
+		if errors.Is(err, types.ErrAuth) || errors.Is(err, types.ErrQuotaExceeded) {
+			return nil, err
+		}
+
+		yc.logger.Warn("Uploads playlist lookup failed, falling back to search", "channelID", channelID, "error", err)
+		videos, err = yc.searchChannelVideos(ctx, channelID, maxResults, publishedAfter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Neither listing includes view counts or durations; the videos are still
+	// usable without them, so a failed lookup only leaves them blank
+	if err := yc.addVideoStats(ctx, videos); err != nil {
+		yc.logger.Warn("Failed to fetch video statistics", "channelID", channelID, "error", err)
+	}
+	return videos, nil
+}
+
+// videoStatsBatchSize is the most IDs the videos endpoint accepts in one request
+const videoStatsBatchSize = 50
+
+// addVideoStats fills in view counts and durations with one videos request per
+// 50 videos
+func (yc *YouTubeClient) addVideoStats(ctx context.Context, videos []types.Video) error {
+	for start := 0; start < len(videos); start += videoStatsBatchSize {
+		end := min(start+videoStatsBatchSize, len(videos))
+		batch := videos[start:end]
+
+		ids := make([]string, len(batch))
+		for i, video := range batch {
+			ids[i] = video.ID
+		}
+
+		params := url.Values{}
+		params.Add("key", yc.apiKey)
+		params.Add("id", strings.Join(ids, ","))
+		params.Add("part", "statistics,contentDetails")
+		params.Add("maxResults", strconv.Itoa(len(ids)))
+
+		fullURL := fmt.Sprintf("%s/videos?%s", yc.baseURL, params.Encode())
+
+		yc.calls.Add(1)
+		resp, err := yc.httpClient.Get(ctx, fullURL)
+		if err != nil {
+			return fmt.Errorf("failed to fetch video statistics: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := youtubeStatusError(resp)
+			resp.Body.Close()
+			return err
+		}
+
+		var apiResponse YouTubeVideoStatsResponse
+		err = json.NewDecoder(resp.Body).Decode(&apiResponse)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode video statistics response: %w", err)
+		}
+
+		stats := make(map[string]YouTubeVideoStatsItem, len(apiResponse.Items))
+		for _, item := range apiResponse.Items {
+			stats[item.ID] = item
+		}
+		for i := range batch {
+			item, ok := stats[batch[i].ID]
+			if !ok {
+				continue
+			}
+			batch[i].Duration = item.ContentDetails.Duration
+			batch[i].ViewCount = parseViewCount(item.Statistics.ViewCount)
+		}
+	}
+	return nil
+}
+
+// parseViewCount parses the API's string view count, treating a missing or
+// malformed count (hidden statistics) as zero
+func parseViewCount(viewCount string) int64 {
+	count, err := strconv.ParseInt(viewCount, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// getUploadsPlaylistVideos retrieves recent videos by paging the channel's uploads playlist.
+// The playlist lists newest uploads first, so paging stops at the first video published
+// at or before publishedAfter.
+func (yc *YouTubeClient) getUploadsPlaylistVideos(ctx context.Context, channelID string, maxResults int, publishedAfter time.Time) ([]types.Video, error) {
+	playlistID, err := yc.getUploadsPlaylistID(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	yc.logger.Debug("Fetching uploads playlist", "channelID", channelID, "playlistID", playlistID, "maxResults", maxResults)
+
+	var videos []types.Video
+	pageToken := ""
+	reachedOlder := false
+	for len(videos) < maxResults && !reachedOlder {
+		// The playlistItems endpoint returns at most 50 items per page
+		pageSize := maxResults - len(videos)
+		if pageSize > 50 {
+			pageSize = 50
+		}
+
+		params := url.Values{}
+		params.Add("key", yc.apiKey)
+		params.Add("playlistId", playlistID)
+		params.Add("part", "snippet,contentDetails")
+		params.Add("maxResults", strconv.Itoa(pageSize))
+		if pageToken != "" {
+			params.Add("pageToken", pageToken)
+		}
+
+		fullURL := fmt.Sprintf("%s/playlistItems?%s", yc.baseURL, params.Encode())
+
+		yc.calls.Add(1)
+		resp, err := yc.httpClient.Get(ctx, fullURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch playlist items: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := youtubeStatusError(resp)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		var apiResponse YouTubePlaylistItemsResponse
+		err = json.NewDecoder(resp.Body).Decode(&apiResponse)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode playlist items response: %w", err)
+		}
+
+		for _, item := range apiResponse.Items {
+			videoID := item.ContentDetails.VideoID
+			if videoID == "" || len(videos) >= maxResults {
+				continue
+			}
+
+			// Prefer the video's publish time over the time it was added to the playlist
+			publishedAt := item.ContentDetails.VideoPublishedAt
+			if publishedAt.IsZero() {
+				publishedAt = item.Snippet.PublishedAt
+			}
+			if !publishedAfter.IsZero() && !publishedAt.After(publishedAfter) {
+				reachedOlder = true
+				continue
+			}
+
+			videos = append(videos, types.Video{
+				ID:          videoID,
+				Title:       item.Snippet.Title,
+				Description: item.Snippet.Description,
+				ChannelID:   item.Snippet.ChannelID,
+				ChannelName: item.Snippet.ChannelTitle,
+				PublishedAt: publishedAt,
+				URL:         fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID),
+			})
+		}
+
+		pageToken = apiResponse.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	yc.logger.Info("Retrieved channel videos", "channelID", channelID, "count", len(videos))
+	return videos, nil
+}
+
+// channelIDPattern matches a YouTube channel ID such as UCxxxxxxxxxxxxxxxxxxxxxx
+var channelIDPattern = regexp.MustCompile(`^UC[0-9A-Za-z_-]{22}$`)
+
+// channelHandlePattern matches a YouTube @handle
+var channelHandlePattern = regexp.MustCompile(`^@[0-9A-Za-z._-]{3,30}$`)
+
+// IsChannelID reports whether s is a channel ID (UC followed by 22 characters)
+func IsChannelID(s string) bool {
+	return channelIDPattern.MatchString(s)
+}
+
+// IsChannelHandle reports whether s is a well-formed @handle
+func IsChannelHandle(s string) bool {
+	return channelHandlePattern.MatchString(s)
+}
+
+// ResolveChannelID returns the channel ID for a channel ID, an @handle, a legacy
+// username or a youtube.com/@handle URL; lookups are cached for the client's lifetime
+func (yc *YouTubeClient) ResolveChannelID(ctx context.Context, idOrHandle string) (string, error) {
+	if channelIDPattern.MatchString(idOrHandle) {
+		return idOrHandle, nil
+	}
+
+	name := strings.TrimSpace(idOrHandle)
+	if i := strings.Index(name, "youtube.com/"); i >= 0 {
+		name = strings.Trim(name[i+len("youtube.com/"):], "/")
+	}
+	if name == "" {
+		return "", fmt.Errorf("channel handle or username is empty")
+	}
+
+	yc.resolvedMu.Lock()
+	channelID, ok := yc.resolved[name]
+	yc.resolvedMu.Unlock()
+	if ok {
+		return channelID, nil
+	}
+
+	handle := name
+	if !strings.HasPrefix(handle, "@") {
+		handle = "@" + handle
+	}
+	channelID, err := yc.lookupChannel(ctx, "forHandle", handle)
+	if err != nil {
+		return "", err
+	}
+	// A bare name may be a legacy username rather than a handle
+	if channelID == "" && !strings.HasPrefix(name, "@") {
+		channelID, err = yc.lookupChannel(ctx, "forUsername", name)
+		if err != nil {
+			return "", err
+		}
+	}
+	if channelID == "" {
+		return "", fmt.Errorf("no YouTube channel matches handle or username %q", idOrHandle)
+	}
+
+	yc.resolvedMu.Lock()
+	yc.resolved[name] = channelID
+	yc.resolvedMu.Unlock()
+
+	yc.logger.Debug("Resolved channel", "name", name, "channelID", channelID)
+	return channelID, nil
+}
+
+// lookupChannel queries the channels endpoint with a forHandle or forUsername
+// filter, returning an empty ID when nothing matches
+func (yc *YouTubeClient) lookupChannel(ctx context.Context, filter, value string) (string, error) {
+	params := url.Values{}
+	params.Add("key", yc.apiKey)
+	params.Add(filter, value)
+	params.Add("part", "id")
+
+	fullURL := fmt.Sprintf("%s/channels?%s", yc.baseURL, params.Encode())
+
+	yc.calls.Add(1)
+	resp, err := yc.httpClient.Get(ctx, fullURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up channel %s: %w", value, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", youtubeStatusError(resp)
+	}
+
+	var apiResponse YouTubeChannelListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return "", fmt.Errorf("failed to decode channel lookup response: %w", err)
+	}
+
+	if len(apiResponse.Items) == 0 {
+		return "", nil
+	}
+	return apiResponse.Items[0].ID, nil
+}
+
+// getUploadsPlaylistID looks up the ID of the channel's uploads playlist
+func (yc *YouTubeClient) getUploadsPlaylistID(ctx context.Context, channelID string) (string, error) {
+	params := url.Values{}
+	params.Add("key", yc.apiKey)
+	params.Add("id", channelID)
+	params.Add("part", "contentDetails")
+
+	fullURL := fmt.Sprintf("%s/channels?%s", yc.baseURL, params.Encode())
+
+	yc.calls.Add(1)
+	resp, err := yc.httpClient.Get(ctx, fullURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch channel details: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", youtubeStatusError(resp)
+	}
+
+	var apiResponse YouTubeChannelListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return "", fmt.Errorf("failed to decode channel details response: %w", err)
+	}
+
+	if len(apiResponse.Items) == 0 {
+		return "", fmt.Errorf("channel not found: %s", channelID)
+	}
+
+	playlistID := apiResponse.Items[0].ContentDetails.RelatedPlaylists.Uploads
+	if playlistID == "" {
+		return "", fmt.Errorf("channel %s has no uploads playlist", channelID)
+	}
+
+	return playlistID, nil
+}
+
+// searchChannelVideos retrieves recent videos using the search endpoint (100 quota units per call)
+func (yc *YouTubeClient) searchChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter time.Time) ([]types.Video, error) {
 	// Build the API URL
 	apiURL := fmt.Sprintf("%s/search", yc.baseURL)
 	params := url.Values{}
@@ -79,12 +456,16 @@ func (yc *YouTubeClient) GetChannelVideos(ctx context.Context, channelID string,
 	params.Add("order", "date")
 	params.Add("type", "video")
 	params.Add("maxResults", strconv.Itoa(maxResults))
+	if !publishedAfter.IsZero() {
+		params.Add("publishedAfter", publishedAfter.UTC().Format(time.RFC3339))
+	}
 
 	fullURL := fmt.Sprintf("%s?%s", apiURL, params.Encode())
 
-	yc.logger.Debug("Fetching channel videos", "channelID", channelID, "maxResults", maxResults)
+	yc.logger.Debug("Searching channel videos", "channelID", channelID, "maxResults", maxResults)
 
 	// Make the API request
+	yc.calls.Add(1)
 	resp, err := yc.httpClient.Get(ctx, fullURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch channel videos: %w", err)
@@ -92,7 +473,7 @@ func (yc *YouTubeClient) GetChannelVideos(ctx context.Context, channelID string,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("YouTube API returned status %d", resp.StatusCode)
+		return nil, youtubeStatusError(resp)
 	}
 
 	// Parse the response
@@ -122,7 +503,7 @@ func (yc *YouTubeClient) GetChannelVideos(ctx context.Context, channelID string,
 		videos = append(videos, video)
 	}
 
-	yc.logger.Info("Retrieved channel videos", "channelID", channelID, "count", len(videos))
+	yc.logger.Info("Retrieved channel videos via search", "channelID", channelID, "count", len(videos))
 	return videos, nil
 }
 
@@ -140,6 +521,7 @@ func (yc *YouTubeClient) GetVideoDetails(ctx context.Context, videoID string) (*
 	yc.logger.Debug("Fetching video details", "videoID", videoID)
 
 	// Make the API request
+	yc.calls.Add(1)
 	resp, err := yc.httpClient.Get(ctx, fullURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch video details: %w", err)
@@ -147,7 +529,7 @@ func (yc *YouTubeClient) GetVideoDetails(ctx context.Context, videoID string) (*
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("YouTube API returned status %d", resp.StatusCode)
+		return nil, youtubeStatusError(resp)
 	}
 
 	// Parse the response
@@ -157,19 +539,11 @@ func (yc *YouTubeClient) GetVideoDetails(ctx context.Context, videoID string) (*
 	}
 
 	if len(apiResponse.Items) == 0 {
-		return nil, fmt.Errorf("video not found: %s", videoID)
+		return nil, fmt.Errorf("%w: %s", types.ErrVideoNotFound, videoID)
 	}
 
 	item := apiResponse.Items[0]
 
-	// Parse view count
-	var viewCount int64
-	if item.Statistics.ViewCount != "" {
-		if count, err := strconv.ParseInt(item.Statistics.ViewCount, 10, 64); err == nil {
-			viewCount = count
-		}
-	}
-
 	video := &types.Video{
 		ID:          videoID,
 		Title:       item.Snippet.Title,
@@ -178,10 +552,69 @@ func (yc *YouTubeClient) GetVideoDetails(ctx context.Context, videoID string) (*
 		ChannelName: item.Snippet.ChannelTitle,
 		PublishedAt: item.Snippet.PublishedAt,
 		Duration:    item.ContentDetails.Duration,
-		ViewCount:   viewCount,
+		ViewCount:   parseViewCount(item.Statistics.ViewCount),
 		URL:         fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID),
 	}
 
 	yc.logger.Debug("Retrieved video details", "videoID", videoID, "title", video.Title)
 	return video, nil
 }
+
+// APICalls returns the number of YouTube API requests made so far
+func (yc *YouTubeClient) APICalls() []types.APICallCount {
+	return []types.APICallCount{{API: "YouTube", Calls: yc.calls.Load()}}
+}
+
+// MockYouTubeClient for testing purposes
+type MockYouTubeClient struct {
+	logger types.Logger
+}
+
+// NewMockYouTubeClient creates a mock YouTube client that returns canned videos
+func NewMockYouTubeClient(logger types.Logger) *MockYouTubeClient {
+	return &MockYouTubeClient{logger: logger}
+}
+
+// GetChannelVideos returns up to maxResults deterministic mock videos for the channel
+func (myc *MockYouTubeClient) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter time.Time) ([]types.Video, error) {
+	myc.logger.Debug("Using mock channel videos", "channelID", channelID, "maxResults", maxResults)
+
+	videos := make([]types.Video, 0, maxResults)
+	for i := 0; i < maxResults; i++ {
+		videoID := fmt.Sprintf("%s-mock-%d", channelID, i+1)
+		video := myc.mockVideo(videoID, channelID, i)
+		if !publishedAfter.IsZero() && !video.PublishedAt.After(publishedAfter) {
+			break
+		}
+		videos = append(videos, video)
+	}
+	return videos, nil
+}
+
+// ResolveChannelID returns the input unchanged
+func (myc *MockYouTubeClient) ResolveChannelID(ctx context.Context, idOrHandle string) (string, error) {
+	return idOrHandle, nil
+}
+
+// GetVideoDetails returns a deterministic mock video
+func (myc *MockYouTubeClient) GetVideoDetails(ctx context.Context, videoID string) (*types.Video, error) {
+	myc.logger.Debug("Using mock video details", "videoID", videoID)
+
+	video := myc.mockVideo(videoID, "UCmockchannel", 0)
+	return &video, nil
+}
+
+// mockVideo builds a canned video, published one day apart by index (newest first)
+func (myc *MockYouTubeClient) mockVideo(videoID, channelID string, index int) types.Video {
+	return types.Video{
+		ID:          videoID,
+		Title:       fmt.Sprintf("Mock Video %d", index+1),
+		Description: fmt.Sprintf("This is the description for mock video %s.", videoID),
+		ChannelID:   channelID,
+		ChannelName: "Mock Channel",
+		PublishedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, -index),
+		Duration:    "PT10M30S",
+		ViewCount:   int64(1000 * (index + 1)),
+		URL:         fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID),
+	}
+}
@@ -2,38 +2,112 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
+	"runtime"
+	"strings"
 	"time"
 
 	"youtube-summarizer/pkg/types"
 )
 
+// supportedTransferEncodings are the values accepted in email.transfer_encoding
+var supportedTransferEncodings = map[string]bool{
+	"quoted-printable": true,
+	"base64":           true,
+	"8bit":             true,
+}
+
+// supportedAIProviders are the values accepted in ai.providers
+var supportedAIProviders = map[string]bool{
+	"claude": true,
+	"openai": true,
+}
+
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() *types.Config {
 	return &types.Config{
 		App: types.AppConfig{
 			MaxVideosOnFirstRun: 10,
+			FirstRunPerChannel:  true,
 		},
 		YouTube: types.YouTubeConfig{
-			MaxVideosPerChannel: 5,
+			MaxVideosPerChannel:    5,
+			DormantRecheckInterval: 24 * time.Hour,
 		},
 		Processing: types.ProcessingConfig{
-			MaxConcurrentVideos: 3,
-			TranscriptTimeout:   30 * time.Second,
+			MaxConcurrentVideos:      0, // auto: see ApplyAutoDefaults
+			MaxConcurrentTranscripts: 3,
+			TranscriptTimeout:        30 * time.Second,
+			ShareTranscriptFetches:   true,
+			Order:                    "newest",
+			VideoRetries:             1,
+			VideoRetryDelay:          30 * time.Second,
 		},
 		Email: types.EmailConfig{
-			SMTPHost:        "smtp.gmail.com",
-			SMTPPort:        587,
-			SubjectTemplate: "YouTube Summary - {date}",
+			SMTPHost:         "smtp.gmail.com",
+			SMTPPort:         587,
+			Auth:             "login",
+			TransferEncoding: "quoted-printable",
+			MinSummaries:     1,
+			SubjectTemplate:  "YouTube Summary - {date}",
+			DigestTitle:      "YouTube Video Digest",
+			DateFormat:       "January 2, 2006",
+			ShowThumbnails:   true,
+			PlainText:        true,
+			RenderWorkers:    4,
+			PDFCommand:       "wkhtmltopdf",
 		},
 		AI: types.AIConfig{
 			MaxTranscriptLength: 15000,
-			SummaryPrompt: `Video Title: "{title}". Summarize the key takeaways from the following video transcript into a concise paragraph. Focus on the main points and actionable advice:
-
-{transcript}`,
+			MaxTokens:           1000,
+			Temperature:         1,
+			SummaryPrompt:       types.DefaultPromptTemplate,
+			Providers:           []string{"claude"},
+			Audience:            "general",
+			ThrottleMinRequests: 1,
+			ThrottleMinTokens:   5000,
+			RateLimitRetries:    3,
+		},
+		Timeouts: types.TimeoutsConfig{
+			YouTube:    30 * time.Second,
+			Transcript: 45 * time.Second,
+			AI:         60 * time.Second,
+			Notion:     30 * time.Second,
+		},
+		Storage: types.StorageConfig{
+			TranscriptDir:  "transcripts",
+			BackupsToKeep:  5,
+			SaveRetries:    5,
+			SaveRetryDelay: 2 * time.Second,
+			Mode:           "history",
+			LatestCount:    200,
+		},
+		Transcript: types.TranscriptConfig{
+			Host:      "youtube-transcriptor.p.rapidapi.com",
+			BaseURL:   "https://youtube-transcriptor.p.rapidapi.com",
+			Languages: []string{"en"},
+		},
+		HTTP: types.HTTPConfig{
+			RetryAttempts:  3,
+			RetryBaseDelay: time.Second,
+			RetryMaxDelay:  30 * time.Second,
 		},
 	}
 }
 
+// maxAutoConcurrentVideos caps the automatic video concurrency so large machines
+// don't flood the AI API with parallel requests
+const maxAutoConcurrentVideos = 8
+
+// ApplyAutoDefaults resolves settings whose zero value means "auto":
+// processing.max_concurrent_videos defaults to the CPU count, capped.
+func ApplyAutoDefaults(c *types.Config) {
+	if c.Processing.MaxConcurrentVideos == 0 {
+		c.Processing.MaxConcurrentVideos = min(runtime.NumCPU(), maxAutoConcurrentVideos)
+	}
+}
+
 // Validate checks if the configuration is valid
 func Validate(c *types.Config) error {
 	if c.App.MaxVideosOnFirstRun <= 0 {
@@ -44,14 +118,54 @@ func Validate(c *types.Config) error {
 		return fmt.Errorf("youtube.max_videos_per_channel must be greater than 0")
 	}
 
-	if c.Processing.MaxConcurrentVideos <= 0 {
-		return fmt.Errorf("processing.max_concurrent_videos must be greater than 0")
+	if c.YouTube.DormantAfter < 0 {
+		return fmt.Errorf("youtube.dormant_after cannot be negative")
+	}
+
+	if c.YouTube.DormantAfter > 0 && c.YouTube.DormantRecheckInterval <= 0 {
+		return fmt.Errorf("youtube.dormant_recheck_interval must be greater than 0 when youtube.dormant_after is set")
+	}
+
+	if c.Processing.MaxConcurrentVideos < 0 {
+		return fmt.Errorf("processing.max_concurrent_videos cannot be negative")
+	}
+
+	if c.Processing.MaxConcurrentTranscripts <= 0 {
+		return fmt.Errorf("processing.max_concurrent_transcripts must be greater than 0")
 	}
 
 	if c.Processing.TranscriptTimeout <= 0 {
 		return fmt.Errorf("processing.transcript_timeout must be greater than 0")
 	}
 
+	if c.Processing.AbortAfterFailures < 0 {
+		return fmt.Errorf("processing.abort_after_failures cannot be negative")
+	}
+
+	if c.Processing.Order != "newest" && c.Processing.Order != "oldest" {
+		return fmt.Errorf("processing.order must be newest or oldest, got %q", c.Processing.Order)
+	}
+
+	if c.Processing.VideoRetries < 0 {
+		return fmt.Errorf("processing.video_retries cannot be negative")
+	}
+
+	if c.Processing.VideoRetries > 0 && c.Processing.VideoRetryDelay <= 0 {
+		return fmt.Errorf("processing.video_retry_delay must be greater than 0 when processing.video_retries is set")
+	}
+
+	if c.Processing.RunTimeout < 0 {
+		return fmt.Errorf("processing.run_timeout cannot be negative")
+	}
+
+	if c.Processing.MinDuration < 0 || c.Processing.MaxDuration < 0 {
+		return fmt.Errorf("processing.min_duration and processing.max_duration cannot be negative")
+	}
+
+	if c.Processing.MaxDuration > 0 && c.Processing.MaxDuration < c.Processing.MinDuration {
+		return fmt.Errorf("processing.max_duration must not be less than processing.min_duration")
+	}
+
 	if c.Email.SMTPHost == "" {
 		return fmt.Errorf("email.smtp_host cannot be empty")
 	}
@@ -60,13 +174,311 @@ func Validate(c *types.Config) error {
 		return fmt.Errorf("email.smtp_port must be greater than 0")
 	}
 
+	if c.Email.DigestTitle == "" {
+		return fmt.Errorf("email.digest_title cannot be empty")
+	}
+
+	if c.Email.HeaderImageURL != "" {
+		if u, err := url.Parse(c.Email.HeaderImageURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("email.header_image_url must be an absolute http(s) URL")
+		}
+	}
+
+	if err := validateDateFormat(c.Email.DateFormat); err != nil {
+		return fmt.Errorf("email.date_format is invalid: %w", err)
+	}
+
+	if c.Email.MaxPerChannel < 0 {
+		return fmt.Errorf("email.max_per_channel cannot be negative")
+	}
+
+	if c.Email.Auth != "login" && c.Email.Auth != "none" {
+		return fmt.Errorf("email.auth must be login or none, got %q", c.Email.Auth)
+	}
+
+	for _, recipient := range c.Email.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("email.recipients contains an invalid address %q: %w", recipient, err)
+		}
+	}
+
+	for _, recipient := range c.Email.CC {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("email.cc contains an invalid address %q: %w", recipient, err)
+		}
+	}
+
+	for _, recipient := range c.Email.BCC {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("email.bcc contains an invalid address %q: %w", recipient, err)
+		}
+	}
+
+	if c.Email.GroupBy != "" && c.Email.GroupBy != "group" {
+		return fmt.Errorf("email.group_by must be empty or group, got %q", c.Email.GroupBy)
+	}
+
+	if len(c.Email.GroupRecipients) > 0 && c.Email.GroupBy != "group" {
+		return fmt.Errorf("email.group_recipients requires email.group_by: group")
+	}
+
+	for group, recipients := range c.Email.GroupRecipients {
+		if len(recipients) == 0 {
+			return fmt.Errorf("email.group_recipients[%s] needs at least one address", group)
+		}
+		for _, recipient := range recipients {
+			if _, err := mail.ParseAddress(recipient); err != nil {
+				return fmt.Errorf("email.group_recipients[%s] contains an invalid address %q: %w", group, recipient, err)
+			}
+		}
+	}
+
+	for route, recipients := range c.Email.Routes {
+		if _, ok := c.Email.GroupRecipients[route]; ok {
+			return fmt.Errorf("email.routes[%s] is also in email.group_recipients; keep it in one of them", route)
+		}
+		if len(recipients) == 0 {
+			return fmt.Errorf("email.routes[%s] needs at least one address", route)
+		}
+		for _, recipient := range recipients {
+			if _, err := mail.ParseAddress(recipient); err != nil {
+				return fmt.Errorf("email.routes[%s] contains an invalid address %q: %w", route, recipient, err)
+			}
+		}
+	}
+
+	if !supportedTransferEncodings[c.Email.TransferEncoding] {
+		return fmt.Errorf("email.transfer_encoding must be quoted-printable, base64 or 8bit, got %q", c.Email.TransferEncoding)
+	}
+
+	if c.Email.ListUnsubscribe != "" {
+		if u, err := url.Parse(c.Email.ListUnsubscribe); err != nil || (u.Scheme != "mailto" && u.Scheme != "https" && u.Scheme != "http") {
+			return fmt.Errorf("email.list_unsubscribe must be a mailto: or https: URL")
+		}
+	}
+
+	if c.Email.SummaryMaxChars < 0 {
+		return fmt.Errorf("email.summary_max_chars cannot be negative")
+	}
+
+	if c.Email.EmbedThumbnails && !c.Email.ShowThumbnails {
+		return fmt.Errorf("email.embed_thumbnails needs email.show_thumbnails")
+	}
+
+	if c.Email.EmbedThumbnails && c.Email.RenderWorkers <= 0 {
+		return fmt.Errorf("email.render_workers must be greater than 0 when embedding thumbnails")
+	}
+
+	if c.Email.AttachPDF && strings.TrimSpace(c.Email.PDFCommand) == "" {
+		return fmt.Errorf("email.pdf_command is required when email.attach_pdf is set")
+	}
+
+	if _, _, err := ParseSendWindow(c.Email.SendWindow); err != nil {
+		return fmt.Errorf("email.send_window is invalid: %w", err)
+	}
+
+	if c.Email.MinDigestInterval < 0 {
+		return fmt.Errorf("email.min_digest_interval cannot be negative")
+	}
+
+	if c.Email.ForceSendAfter < 0 {
+		return fmt.Errorf("email.force_send_after cannot be negative")
+	}
+
+	if c.Email.MinSummaries < 1 {
+		return fmt.Errorf("email.min_summaries must be at least 1")
+	}
+
 	if c.AI.MaxTranscriptLength <= 0 {
 		return fmt.Errorf("ai.max_transcript_length must be greater than 0")
 	}
 
-	if c.AI.SummaryPrompt == "" {
-		return fmt.Errorf("ai.summary_prompt cannot be empty")
+	if c.AI.MinTranscriptLength < 0 {
+		return fmt.Errorf("ai.min_transcript_length cannot be negative")
+	}
+
+	if c.AI.MaxTokens <= 0 {
+		return fmt.Errorf("ai.max_tokens must be positive")
+	}
+
+	if c.AI.Temperature < 0 || c.AI.Temperature > 1 {
+		return fmt.Errorf("ai.temperature must be between 0 and 1, got %g", c.AI.Temperature)
+	}
+
+	if c.AI.Audience != "beginner" && c.AI.Audience != "general" && c.AI.Audience != "expert" {
+		return fmt.Errorf("ai.audience must be beginner, general or expert, got %q", c.AI.Audience)
+	}
+
+	if len(c.AI.Providers) == 0 {
+		return fmt.Errorf("ai.providers must list at least one provider")
+	}
+
+	if c.AI.RateLimitRetries < 0 {
+		return fmt.Errorf("ai.rate_limit_retries cannot be negative")
+	}
+
+	if c.AI.ThrottleMinRequests < 0 || c.AI.ThrottleMinTokens < 0 {
+		return fmt.Errorf("ai.throttle_min_requests and ai.throttle_min_tokens cannot be negative")
+	}
+
+	for channel, markers := range c.AI.ChannelMarkers {
+		if strings.TrimSpace(markers.IntroEnd) == "" && strings.TrimSpace(markers.OutroStart) == "" {
+			return fmt.Errorf("ai.channel_markers[%s] needs intro_end or outro_start", channel)
+		}
+	}
+
+	for i, example := range c.AI.Examples {
+		if strings.TrimSpace(example.Input) == "" || strings.TrimSpace(example.Output) == "" {
+			return fmt.Errorf("ai.examples[%d] needs both input and output", i)
+		}
+	}
+
+	seenProviders := make(map[string]bool)
+	for _, provider := range c.AI.Providers {
+		if !supportedAIProviders[provider] {
+			return fmt.Errorf("ai.providers contains unsupported provider %q (supported: claude, openai)", provider)
+		}
+		if seenProviders[provider] {
+			return fmt.Errorf("ai.providers lists %q more than once", provider)
+		}
+		seenProviders[provider] = true
+	}
+
+	if c.Timeouts.YouTube <= 0 {
+		return fmt.Errorf("timeouts.youtube must be greater than 0")
+	}
+
+	if c.Timeouts.Transcript <= 0 {
+		return fmt.Errorf("timeouts.transcript must be greater than 0")
+	}
+
+	if c.Timeouts.AI <= 0 {
+		return fmt.Errorf("timeouts.ai must be greater than 0")
+	}
+
+	if c.Timeouts.Notion <= 0 {
+		return fmt.Errorf("timeouts.notion must be greater than 0")
+	}
+
+	if c.Storage.SaveTranscripts && c.Storage.TranscriptDir == "" {
+		return fmt.Errorf("storage.transcript_dir cannot be empty when storage.save_transcripts is enabled")
+	}
+
+	if c.Storage.BackupsToKeep < 0 {
+		return fmt.Errorf("storage.backups_to_keep cannot be negative")
+	}
+
+	if c.Storage.SaveRetries < 0 {
+		return fmt.Errorf("storage.save_retries cannot be negative")
+	}
+
+	if c.Storage.SaveRetries > 0 && c.Storage.SaveRetryDelay <= 0 {
+		return fmt.Errorf("storage.save_retry_delay must be greater than 0 when retries are enabled")
+	}
+
+	switch c.Storage.Mode {
+	case "history":
+	case "latest":
+		if c.Storage.LatestCount <= 0 {
+			return fmt.Errorf("storage.latest_count must be greater than 0 in latest mode")
+		}
+	default:
+		return fmt.Errorf("storage.mode must be history or latest, got %q", c.Storage.Mode)
+	}
+
+	if c.Transcript.Host == "" {
+		return fmt.Errorf("transcript.host cannot be empty")
+	}
+
+	if u, err := url.Parse(c.Transcript.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("transcript.base_url must be an absolute URL")
+	}
+
+	if len(c.Transcript.Languages) == 0 {
+		return fmt.Errorf("transcript.languages must list at least one language")
+	}
+	for _, lang := range c.Transcript.Languages {
+		if strings.TrimSpace(lang) == "" {
+			return fmt.Errorf("transcript.languages cannot contain an empty language")
+		}
+	}
+
+	if c.HTTP.Proxy != "" {
+		if u, err := url.Parse(c.HTTP.Proxy); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("http.proxy must be an absolute URL, e.g. http://proxy.example.com:8080")
+		}
+	}
+
+	if c.HTTP.RetryAttempts < 1 {
+		return fmt.Errorf("http.retry_attempts must be at least 1")
+	}
+
+	if c.HTTP.RetryAttempts > 1 && (c.HTTP.RetryBaseDelay <= 0 || c.HTTP.RetryMaxDelay < c.HTTP.RetryBaseDelay) {
+		return fmt.Errorf("http.retry_base_delay must be greater than 0 and at most http.retry_max_delay")
 	}
 
 	return nil
 }
+
+// validateDateFormat checks that a Go time layout contains date elements and round-trips
+func validateDateFormat(layout string) error {
+	if layout == "" {
+		return fmt.Errorf("cannot be empty")
+	}
+
+	sample := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	formatted := sample.Format(layout)
+	if formatted == layout {
+		return fmt.Errorf("%q has no date elements (layouts use the reference date, e.g. 2006-01-02)", layout)
+	}
+	if _, err := time.Parse(layout, formatted); err != nil {
+		return fmt.Errorf("%q is not a valid Go time layout: %w", layout, err)
+	}
+	return nil
+}
+
+// ParseSendWindow parses a "HH:MM-HH:MM" window into offsets from midnight.
+// An empty window parses to a zero-length range, meaning "always".
+func ParseSendWindow(window string) (time.Duration, time.Duration, error) {
+	if window == "" {
+		return 0, 0, nil
+	}
+
+	parts := strings.Split(window, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected format HH:MM-HH:MM, got %q", window)
+	}
+
+	var bounds [2]time.Duration
+	for i, part := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(part))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid time %q: %w", part, err)
+		}
+		bounds[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	if bounds[0] == bounds[1] {
+		return 0, 0, fmt.Errorf("window start and end cannot be equal")
+	}
+
+	return bounds[0], bounds[1], nil
+}
+
+// InSendWindow reports whether now falls within the configured send window.
+// Windows that wrap past midnight (e.g. "22:00-02:00") are supported.
+func InSendWindow(window string, now time.Time) (bool, error) {
+	start, end, err := ParseSendWindow(window)
+	if err != nil {
+		return false, err
+	}
+	if start == end {
+		return true, nil
+	}
+
+	offset := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+	if start < end {
+		return offset >= start && offset < end, nil
+	}
+	return offset >= start || offset < end, nil
+}
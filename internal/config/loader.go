@@ -2,9 +2,14 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"youtube-summarizer/pkg/types"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
@@ -12,14 +17,58 @@ import (
 type Loader struct {
 	configPath string
 	envPath    string
+	// Each loader has its own viper instance so profiles don't leak into each other
+	viper *viper.Viper
+	// Sections absent from the config file after the last Load
+	missingSections []string
 }
 
+// configSections are the top-level config.yaml sections
+var configSections = []string{"app", "youtube", "processing", "email", "ai", "timeouts", "storage", "transcript", "notion", "http"}
+
 // NewLoader creates a new configuration loader
 func NewLoader(configPath, envPath string) *Loader {
 	return &Loader{
 		configPath: configPath,
 		envPath:    envPath,
+		viper:      viper.New(),
+	}
+}
+
+// NewProfileLoader creates a loader for the named profile in a config directory,
+// reading <profile>.yaml or, when that doesn't exist, <profile>.yml
+func NewProfileLoader(configDir, profile, envPath string) *Loader {
+	path := filepath.Join(configDir, profile+".yaml")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		yml := filepath.Join(configDir, profile+".yml")
+		if _, err := os.Stat(yml); err == nil {
+			path = yml
+		}
 	}
+	return NewLoader(path, envPath)
+}
+
+// ListProfiles returns the sorted names of all profile configs (*.yaml, *.yml) in a directory
+func ListProfiles(configDir string) ([]string, error) {
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %w", err)
+	}
+
+	var profiles []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		profiles = append(profiles, strings.TrimSuffix(entry.Name(), ext))
+	}
+
+	sort.Strings(profiles)
+	return profiles, nil
 }
 
 // Load loads configuration from config file only (single source of truth)
@@ -28,24 +77,35 @@ func (l *Loader) Load() (*types.Config, error) {
 	config := DefaultConfig()
 
 	// Set up viper to read from config file only
-	viper.SetConfigFile(l.configPath)
-	viper.SetConfigType("yaml")
+	l.viper.SetConfigFile(l.configPath)
+	l.viper.SetConfigType("yaml")
 
 	// Read config file (required for proper operation)
-	if err := viper.ReadInConfig(); err != nil {
+	l.missingSections = nil
+	if err := l.viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			// Config file not found - use defaults
 			// This is acceptable for testing but log a warning
 		} else {
 			return nil, fmt.Errorf("failed to read config file: %w", err)
 		}
+	} else {
+		for _, section := range configSections {
+			if !l.viper.InConfig(section) {
+				l.missingSections = append(l.missingSections, section)
+			}
+		}
 	}
 
-	// Unmarshal into our config struct
-	if err := viper.Unmarshal(config); err != nil {
+	// Unmarshal into our config struct; the structs carry yaml tags, so decode by
+	// those instead of mapstructure's default field-name matching
+	if err := l.viper.Unmarshal(config, useYAMLTags); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Resolve "auto" settings such as a zero max_concurrent_videos
+	ApplyAutoDefaults(config)
+
 	// Validate the configuration
 	if err := Validate(config); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -54,17 +114,38 @@ func (l *Loader) Load() (*types.Config, error) {
 	return config, nil
 }
 
+// useYAMLTags makes viper match config keys against the yaml struct tags
+func useYAMLTags(c *mapstructure.DecoderConfig) {
+	c.TagName = "yaml"
+}
+
+// MissingSections returns the top-level sections that were absent from the
+// config file on the last Load and therefore fell back to defaults
+func (l *Loader) MissingSections() []string {
+	return l.missingSections
+}
+
+// ConfigPath returns the path of the config file this loader reads
+func (l *Loader) ConfigPath() string {
+	return l.configPath
+}
+
 // Removed LoadFromEnvironment - config.yaml is the single source of truth
 
 // SaveConfig saves configuration to the specified file (for UI integration)
 func (l *Loader) SaveConfig(config *types.Config) error {
-	viper.Set("app", config.App)
-	viper.Set("youtube", config.YouTube)
-	viper.Set("processing", config.Processing)
-	viper.Set("email", config.Email)
-	viper.Set("ai", config.AI)
+	l.viper.Set("app", config.App)
+	l.viper.Set("youtube", config.YouTube)
+	l.viper.Set("processing", config.Processing)
+	l.viper.Set("email", config.Email)
+	l.viper.Set("ai", config.AI)
+	l.viper.Set("timeouts", config.Timeouts)
+	l.viper.Set("storage", config.Storage)
+	l.viper.Set("transcript", config.Transcript)
+	l.viper.Set("notion", config.Notion)
+	l.viper.Set("http", config.HTTP)
 
-	return viper.WriteConfigAs(l.configPath)
+	return l.viper.WriteConfigAs(l.configPath)
 }
 
 // bindEnvVars manually binds environment variables to viper keys
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// fullConfigYAML sets every config key, each to a value other than its default
+const fullConfigYAML = `
+app:
+  max_videos_on_first_run: 3
+  first_run_per_channel: false
+youtube:
+  max_videos_per_channel: 7
+  channels: ["UCabc", "UCdef"]
+  dormant_after: 720h
+  dormant_recheck_interval: 48h
+processing:
+  max_concurrent_videos: 2
+  max_concurrent_transcripts: 4
+  transcript_timeout: 20s
+  share_transcript_fetches: false
+  abort_after_failures: 5
+  order: oldest
+  video_retries: 2
+  video_retry_delay: 10s
+  run_timeout: 1h
+  min_duration: 2m
+  max_duration: 90m
+email:
+  smtp_host: smtp.example.com
+  smtp_port: 2525
+  auth: none
+  subject_template: "Digest {date}"
+  digest_title: "My Digest"
+  header_image_url: https://example.com/logo.png
+  date_format: "2006-01-02"
+  send_window: "07:00-09:00"
+  min_digest_interval: 12h
+  min_summaries: 2
+  force_send_after: 72h
+  include_intro: true
+  roundup_themes: true
+  show_thumbnails: true
+  embed_thumbnails: true
+  render_workers: 8
+  verify_videos: true
+  max_per_channel: 3
+  render_markdown: true
+  summary_max_chars: 500
+  plain_text: false
+  attach_pdf: true
+  pdf_command: "chromium --headless"
+  recipients: ["a@example.com"]
+  cc: ["c@example.com"]
+  bcc: ["b@example.com"]
+  send_to_self: true
+  transfer_encoding: base64
+  list_unsubscribe: mailto:unsubscribe@example.com
+  group_by: group
+  group_recipients:
+    tech: ["tech@example.com"]
+  routes:
+    news: ["news@example.com"]
+ai:
+  max_transcript_length: 20000
+  min_transcript_length: 100
+  max_tokens: 2000
+  temperature: 0.3
+  summary_prompt: "Summarize {title}: {transcript}"
+  prompts:
+    tutorial: "Steps for {title}: {transcript}"
+  questions: ["What is new?"]
+  audience: expert
+  extract_quotes: true
+  log_requests: true
+  providers: ["openai", "claude"]
+  throttle_min_requests: 2
+  throttle_min_tokens: 1000
+  rate_limit_retries: 1
+  strip_phrases: ["like and subscribe"]
+  channel_markers:
+    ucabc:
+      intro_end: "let's get started"
+      outro_start: "thanks for watching"
+  examples:
+    - title: Example
+      input: a transcript
+      output: a summary
+timeouts:
+  youtube: 10s
+  transcript: 15s
+  ai: 2m
+  notion: 20s
+storage:
+  excel_path: data/profile.xlsx
+  summary_cache_dir: cache
+  save_transcripts: true
+  transcript_dir: texts
+  thumbnail_dir: thumbs
+  backups_to_keep: 2
+  save_retries: 1
+  save_retry_delay: 5s
+  mode: latest
+  latest_count: 50
+transcript:
+  host: transcripts.example.com
+  base_url: https://transcripts.example.com
+  languages: ["de", "en"]
+notion:
+  database_id: db123
+http:
+  proxy: http://proxy.example.com:8080
+  retry_attempts: 5
+  retry_base_delay: 2s
+  retry_max_delay: 1m
+`
+
+func TestLoadReadsEveryKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(fullConfigYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := NewLoader(path, "")
+	got, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if missing := loader.MissingSections(); len(missing) > 0 {
+		t.Errorf("MissingSections() = %v, want none", missing)
+	}
+
+	want := &types.Config{
+		App: types.AppConfig{
+			MaxVideosOnFirstRun: 3,
+			FirstRunPerChannel:  false,
+		},
+		YouTube: types.YouTubeConfig{
+			MaxVideosPerChannel:    7,
+			Channels:               []string{"UCabc", "UCdef"},
+			DormantAfter:           720 * time.Hour,
+			DormantRecheckInterval: 48 * time.Hour,
+		},
+		Processing: types.ProcessingConfig{
+			MaxConcurrentVideos:      2,
+			MaxConcurrentTranscripts: 4,
+			TranscriptTimeout:        20 * time.Second,
+			ShareTranscriptFetches:   false,
+			AbortAfterFailures:       5,
+			Order:                    "oldest",
+			VideoRetries:             2,
+			VideoRetryDelay:          10 * time.Second,
+			RunTimeout:               time.Hour,
+			MinDuration:              2 * time.Minute,
+			MaxDuration:              90 * time.Minute,
+		},
+		Email: types.EmailConfig{
+			SMTPHost:          "smtp.example.com",
+			SMTPPort:          2525,
+			Auth:              "none",
+			SubjectTemplate:   "Digest {date}",
+			DigestTitle:       "My Digest",
+			HeaderImageURL:    "https://example.com/logo.png",
+			DateFormat:        "2006-01-02",
+			SendWindow:        "07:00-09:00",
+			MinDigestInterval: 12 * time.Hour,
+			MinSummaries:      2,
+			ForceSendAfter:    72 * time.Hour,
+			IncludeIntro:      true,
+			RoundupThemes:     true,
+			ShowThumbnails:    true,
+			EmbedThumbnails:   true,
+			RenderWorkers:     8,
+			VerifyVideos:      true,
+			MaxPerChannel:     3,
+			RenderMarkdown:    true,
+			SummaryMaxChars:   500,
+			PlainText:         false,
+			AttachPDF:         true,
+			PDFCommand:        "chromium --headless",
+			Recipients:        []string{"a@example.com"},
+			CC:                []string{"c@example.com"},
+			BCC:               []string{"b@example.com"},
+			SendToSelf:        true,
+			TransferEncoding:  "base64",
+			ListUnsubscribe:   "mailto:unsubscribe@example.com",
+			GroupBy:           "group",
+			GroupRecipients:   map[string][]string{"tech": {"tech@example.com"}},
+			Routes:            map[string][]string{"news": {"news@example.com"}},
+		},
+		AI: types.AIConfig{
+			MaxTranscriptLength: 20000,
+			MinTranscriptLength: 100,
+			MaxTokens:           2000,
+			Temperature:         0.3,
+			SummaryPrompt:       "Summarize {title}: {transcript}",
+			Prompts:             map[string]string{"tutorial": "Steps for {title}: {transcript}"},
+			Questions:           []string{"What is new?"},
+			Audience:            "expert",
+			ExtractQuotes:       true,
+			LogRequests:         true,
+			Providers:           []string{"openai", "claude"},
+			ThrottleMinRequests: 2,
+			ThrottleMinTokens:   1000,
+			RateLimitRetries:    1,
+			StripPhrases:        []string{"like and subscribe"},
+			ChannelMarkers: map[string]types.TranscriptMarkers{
+				"ucabc": {IntroEnd: "let's get started", OutroStart: "thanks for watching"},
+			},
+			Examples: []types.AIExample{{Title: "Example", Input: "a transcript", Output: "a summary"}},
+		},
+		Timeouts: types.TimeoutsConfig{
+			YouTube:    10 * time.Second,
+			Transcript: 15 * time.Second,
+			AI:         2 * time.Minute,
+			Notion:     20 * time.Second,
+		},
+		Storage: types.StorageConfig{
+			ExcelPath:       "data/profile.xlsx",
+			SummaryCacheDir: "cache",
+			SaveTranscripts: true,
+			TranscriptDir:   "texts",
+			ThumbnailDir:    "thumbs",
+			BackupsToKeep:   2,
+			SaveRetries:     1,
+			SaveRetryDelay:  5 * time.Second,
+			Mode:            "latest",
+			LatestCount:     50,
+		},
+		Transcript: types.TranscriptConfig{
+			Host:      "transcripts.example.com",
+			BaseURL:   "https://transcripts.example.com",
+			Languages: []string{"de", "en"},
+		},
+		Notion: types.NotionConfig{
+			DatabaseID: "db123",
+		},
+		HTTP: types.HTTPConfig{
+			Proxy:          "http://proxy.example.com:8080",
+			RetryAttempts:  5,
+			RetryBaseDelay: 2 * time.Second,
+			RetryMaxDelay:  time.Minute,
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestLoadShippedConfig(t *testing.T) {
+	if _, err := NewLoader(filepath.Join("..", "..", "configs", "config.yaml"), "").Load(); err != nil {
+		t.Fatalf("configs/config.yaml does not load: %v", err)
+	}
+}
+
+func TestProfileLoaderFindsListedProfiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"work.yaml", "home.yml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("youtube:\n  max_videos_per_channel: 4\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	profiles, err := ListProfiles(dir)
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if want := []string{"home", "work"}; !reflect.DeepEqual(profiles, want) {
+		t.Fatalf("ListProfiles() = %v, want %v", profiles, want)
+	}
+
+	for _, profile := range profiles {
+		cfg, err := NewProfileLoader(dir, profile, "").Load()
+		if err != nil {
+			t.Errorf("profile %s: Load() error = %v", profile, err)
+			continue
+		}
+		if cfg.YouTube.MaxVideosPerChannel != 4 {
+			t.Errorf("profile %s: max_videos_per_channel = %d, want 4", profile, cfg.YouTube.MaxVideosPerChannel)
+		}
+	}
+}
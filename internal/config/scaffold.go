@@ -0,0 +1,320 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// WriteDefaultConfig writes a commented config.yaml populated from DefaultConfig.
+// An existing file is only replaced when force is set.
+func WriteDefaultConfig(path string, force bool) error {
+	tmpl, err := template.New("config").Funcs(template.FuncMap{
+		"indent": func(spaces int, s string) string {
+			pad := strings.Repeat(" ", spaces)
+			return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+		},
+	}).Parse(configScaffold)
+	if err != nil {
+		return fmt.Errorf("failed to parse config scaffold: %w", err)
+	}
+
+	var content strings.Builder
+	if err := tmpl.Execute(&content, DefaultConfig()); err != nil {
+		return fmt.Errorf("failed to render config scaffold: %w", err)
+	}
+
+	return writeScaffoldFile(path, content.String(), force)
+}
+
+// WriteEnvTemplate writes a .env template listing the API keys and credentials.
+// An existing file is only replaced when force is set.
+func WriteEnvTemplate(path string, force bool) error {
+	return writeScaffoldFile(path, envScaffold, force)
+}
+
+// writeScaffoldFile creates path (and its directory), refusing to replace an existing file unless forced
+func writeScaffoldFile(path, content string, force bool) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+
+	file, err := os.OpenFile(path, flags, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists (use -force to overwrite)", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// configScaffold is the commented config.yaml written by -init
+const configScaffold = `# YouTube Summarizer Configuration
+
+app:
+  # Maximum videos to process on first run (to avoid overwhelming when starting fresh)
+  max_videos_on_first_run: {{.App.MaxVideosOnFirstRun}}
+  # Apply that limit to each channel the first time it's processed (tracked in the
+  # ChannelHistory sheet, so -prune-processed doesn't reset it); false applies it
+  # only to the very first run
+  first_run_per_channel: {{.App.FirstRunPerChannel}}
+
+youtube:
+  # Maximum videos to process per channel each run
+  max_videos_per_channel: {{.YouTube.MaxVideosPerChannel}}
+  # Channel IDs to monitor in addition to the Channels sheet (duplicates are
+  # ignored); a single comma-separated entry also works
+  channels: []
+  # Channels with no upload for dormant_after (e.g. "720h") are only fetched once
+  # per dormant_recheck_interval, saving a search call per run ("0s" = always fetch)
+  dormant_after: "{{.YouTube.DormantAfter}}"
+  dormant_recheck_interval: "{{.YouTube.DormantRecheckInterval}}"
+
+processing:
+  # Videos summarized in parallel (0 = auto: number of CPUs, up to 8)
+  max_concurrent_videos: {{.Processing.MaxConcurrentVideos}}
+  # Transcript fetches run concurrently, bounded separately from AI calls
+  max_concurrent_transcripts: {{.Processing.MaxConcurrentTranscripts}}
+  transcript_timeout: "{{.Processing.TranscriptTimeout}}"
+  # Let channels that surface the same video (e.g. cross-posts) in one run share a
+  # single transcript fetch instead of paying for it twice
+  share_transcript_fetches: {{.Processing.ShareTranscriptFetches}}
+  # Abort the whole run after this many consecutive AI failures, e.g. an expired key (0 = disabled)
+  abort_after_failures: {{.Processing.AbortAfterFailures}}
+  # Process each channel's videos "newest" first, or "oldest" first for backfills;
+  # with the first-run limit, newest drops the older videos and oldest leaves the
+  # newer ones for later runs
+  order: "{{.Processing.Order}}"
+  # Retry a video from the start this many times, after this delay, when it fails
+  # on a rate limit, timeout or server error (invalid keys etc. aren't retried)
+  video_retries: {{.Processing.VideoRetries}}
+  video_retry_delay: "{{.Processing.VideoRetryDelay}}"
+  # Stop the whole run after this long so cron runs can't overlap, e.g. "50m";
+  # summaries finished by then are kept for the next digest ("0s" = no limit; -timeout overrides)
+  run_timeout: "{{.Processing.RunTimeout}}"
+  # Only summarize videos within this length band, e.g. "2m" to skip Shorts and
+  # "90m" to skip long streams ("0s" = no bound; costs one YouTube API call per new video)
+  min_duration: "{{.Processing.MinDuration}}"
+  max_duration: "{{.Processing.MaxDuration}}"
+
+email:
+  smtp_host: "{{.Email.SMTPHost}}"
+  smtp_port: {{.Email.SMTPPort}}
+  # "login" authenticates with EMAIL_USERNAME/EMAIL_PASSWORD; "none" sends through
+  # an unauthenticated relay such as a local Postfix on localhost:25
+  auth: "{{.Email.Auth}}"
+  # Digest recipients; empty sends it to EMAIL_USERNAME only
+  recipients: []
+  # Addresses copied (cc) or blind-copied (bcc) on every email
+  cc: []
+  bcc: []
+  # Also BCC EMAIL_USERNAME a copy when recipients are set (for archival)
+  send_to_self: {{.Email.SendToSelf}}
+  # "group" splits the digest into sections by the Group column of the Channels
+  # sheet (empty = one list)
+  group_by: "{{.Email.GroupBy}}"
+  # With group_by "group", send these groups as separate digests to their own
+  # recipients; other groups stay in the main digest, e.g.
+  #   News: ["news-team@example.com"]
+  group_recipients: {}
+  # Send the summaries of a channel group or channel ID as a separate digest to its
+  # own recipients (a channel ID wins over its group); the rest go to recipients, e.g.
+  #   Tech: ["me@work.example.com"]
+  #   UCxxxxxx: ["me@home.example.com"]
+  routes: {}
+  # Content-Transfer-Encoding of the HTML body: quoted-printable, base64 or 8bit
+  transfer_encoding: "{{.Email.TransferEncoding}}"
+  # List-Unsubscribe header, e.g. "mailto:digest@example.com?subject=unsubscribe"
+  # or an https: link; helps spam filters treat the digest as a newsletter (empty = none)
+  list_unsubscribe: "{{.Email.ListUnsubscribe}}"
+  subject_template: "{{.Email.SubjectTemplate}}" # placeholders: {date}, {count}, {channels}
+  # Heading of the digest email and an optional logo/banner image shown above it
+  digest_title: "{{.Email.DigestTitle}}"
+  header_image_url: "{{.Email.HeaderImageURL}}"
+  # Go time layout for {date} and the digest header, e.g. "2006-01-02" or "Mon 2 Jan"
+  date_format: "{{.Email.DateFormat}}"
+  # Only send the digest between these local times, e.g. "07:00-09:00" (empty = always)
+  send_window: "{{.Email.SendWindow}}"
+  # Minimum time between digests, e.g. "20h" for an hourly cron; summaries made in
+  # between wait for the next digest ("0s" = send on every run)
+  min_digest_interval: "{{.Email.MinDigestInterval}}"
+  # Only send a digest once at least this many summaries are pending; fewer wait
+  # for a later run
+  min_summaries: {{.Email.MinSummaries}}
+  # Send the digest anyway, ignoring the three settings above, once a pending
+  # summary is older than this, e.g. "48h", so none get stuck ("0s" = never force)
+  force_send_after: "{{.Email.ForceSendAfter}}"
+  # Add a short AI-written overview of the day's videos under the header (one extra AI call)
+  include_intro: {{.Email.IncludeIntro}}
+  # Add an AI-written top-themes overview to the -weekly-roundup email (one extra AI call)
+  roundup_themes: {{.Email.RoundupThemes}}
+  # false sends a compact, text-forward digest with no images at all (no thumbnails
+  # or header image), which loads instantly on metered connections
+  show_thumbnails: {{.Email.ShowThumbnails}}
+  # Attach thumbnails inline instead of linking to YouTube, downloading them
+  # with this many parallel workers
+  embed_thumbnails: {{.Email.EmbedThumbnails}}
+  render_workers: {{.Email.RenderWorkers}}
+  # Before sending, drop summaries whose video was deleted or made private
+  # (costs one YouTube API call per video)
+  verify_videos: {{.Email.VerifyVideos}}
+  # Show at most this many summaries per channel (newest first); the rest
+  # are listed as links under the cards (0 = no cap)
+  max_per_channel: {{.Email.MaxPerChannel}}
+  # Render markdown in summaries (bold, lists) as sanitized HTML instead of raw text
+  render_markdown: {{.Email.RenderMarkdown}}
+  # Shorten summaries longer than this many characters to a "read more" link;
+  # the full text stays in storage (0 = show in full)
+  summary_max_chars: {{.Email.SummaryMaxChars}}
+  # Also include a plain-text version of the digest, which screen readers,
+  # text-only mail clients and spam filters use instead of the HTML
+  plain_text: {{.Email.PlainText}}
+  # Attach the digest as a PDF for saving or printing, converted by pdf_command
+  # (wkhtmltopdf or a compatible tool reading HTML on stdin); if conversion fails
+  # the digest is sent without it. Inline thumbnails aren't included in the PDF
+  attach_pdf: {{.Email.AttachPDF}}
+  pdf_command: "{{.Email.PDFCommand}}"
+
+ai:
+  max_transcript_length: {{.AI.MaxTranscriptLength}}
+  # Skip summarizing transcripts shorter than this many characters (0 = disabled)
+  min_transcript_length: {{.AI.MinTranscriptLength}}
+  # Longest Claude response, in tokens (raise it for longer summaries)
+  max_tokens: {{.AI.MaxTokens}}
+  # Claude sampling temperature from 0 (most deterministic) to 1
+  temperature: {{.AI.Temperature}}
+  # Prompt template; {title} and {transcript} are filled in (either may be left out)
+  # and an empty prompt uses the built-in one
+  summary_prompt: |
+{{indent 4 .AI.SummaryPrompt}}
+  # Optional category-specific prompts, chosen by keywords in the video title
+  # (tutorial, news, review). Unmatched videos use the default prompt.
+  prompts: {}
+  # Optional questions to answer per video instead of a summary; the email
+  # shows them as a Q&A list. Leave empty for normal summaries.
+  questions: []
+  # Who summaries are written for: "beginner" (plain words, jargon explained),
+  # "general" or "expert" (assumes background, more depth); recorded per summary
+  audience: "{{.AI.Audience}}"
+  # Also pull 2-3 verbatim notable quotes from each transcript, shown under the
+  # summary (one extra AI call per video; videos without any get none)
+  extract_quotes: {{.AI.ExtractQuotes}}
+  # Log full prompts and raw AI responses at debug level, even without -dev
+  log_requests: {{.AI.LogRequests}}
+  # AI providers to try in order; later ones are used when earlier ones fail
+  # (keys: CLAUDE_API_KEY, OPENAI_API_KEY)
+  providers: [{{range $i, $p := .AI.Providers}}{{if $i}}, {{end}}"{{$p}}"{{end}}]
+  # Pause Claude requests until the rate limit resets once fewer than this many
+  # requests or tokens remain (read from anthropic-ratelimit-* headers; 0 = off)
+  throttle_min_requests: {{.AI.ThrottleMinRequests}}
+  throttle_min_tokens: {{.AI.ThrottleMinTokens}}
+  # Retry a Claude request rejected as rate limited (429) up to this many times,
+  # waiting as long as its Retry-After or rate-limit headers ask (0 = off)
+  rate_limit_retries: {{.AI.RateLimitRetries}}
+  # Phrases removed from every transcript before summarizing, e.g. a sponsor read
+  # repeated in each video (case-insensitive)
+  strip_phrases: []
+  # Per-channel intro/outro markers, keyed by channel ID or name: the transcript
+  # up to the end of intro_end and from the last outro_start on is dropped, e.g.
+  #   UCxxxxxx: {intro_end: "let's get into it", outro_start: "thanks for watching"}
+  channel_markers: {}
+  # Optional few-shot examples for consistent summary style: each input (a
+  # transcript, with an optional title) is sent with its output as earlier turns
+  # of the conversation, e.g.
+  #   - title: "Weekly market update"
+  #     input: "Today stocks fell as..."
+  #     output: "- Stocks fell 2% on..."
+  examples: []
+
+timeouts:
+  # Per-client HTTP request timeouts
+  youtube: "{{.Timeouts.YouTube}}"
+  transcript: "{{.Timeouts.Transcript}}"
+  ai: "{{.Timeouts.AI}}"
+  notion: "{{.Timeouts.Notion}}" # -export-notion
+
+storage:
+  # Cache thumbnails here for the HTTP UI (-serve); empty disables the cache
+  thumbnail_dir: "{{.Storage.ThumbnailDir}}"
+  # Reuse AI summaries for the same video and prompt (e.g. after -prune-processed); empty disables
+  summary_cache_dir: "{{.Storage.SummaryCacheDir}}"
+  # Save each full transcript to transcript_dir/<videoID>.txt for offline re-analysis;
+  # -resummarize-model-before reuses them instead of fetching again
+  save_transcripts: {{.Storage.SaveTranscripts}}
+  transcript_dir: "{{.Storage.TranscriptDir}}"
+  # Number of rotating Excel backups taken before each run or command that writes to the file (0 = disabled)
+  backups_to_keep: {{.Storage.BackupsToKeep}}
+  # Retry saves while the Excel file is locked (e.g. open in Excel), doubling the delay each time
+  save_retries: {{.Storage.SaveRetries}}
+  save_retry_delay: "{{.Storage.SaveRetryDelay}}"
+  # "history" keeps every summary; "latest" keeps only the newest latest_count,
+  # deleting the oldest sent summaries on save (pending ones are kept until sent)
+  mode: "{{.Storage.Mode}}"
+  latest_count: {{.Storage.LatestCount}}
+
+transcript:
+  # RapidAPI transcript provider (x-rapidapi-host header and endpoint)
+  host: "{{.Transcript.Host}}"
+  base_url: "{{.Transcript.BaseURL}}"
+  # Preferred transcript languages in order; a video with none of them uses its
+  # first available language ("auto" = accept the provider's default)
+  languages: [{{range $i, $l := .Transcript.Languages}}{{if $i}}, {{end}}"{{$l}}"{{end}}]
+
+notion:
+  # Database for -export-notion (token in NOTION_API_KEY). The database needs
+  # properties: Name (title), Channel (text), URL (url), Published (date)
+  database_id: "{{.Notion.DatabaseID}}"
+
+http:
+  # Proxy for all API requests (YouTube, AI, RapidAPI, Notion), e.g.
+  # "http://proxy.example.com:8080"; empty uses HTTPS_PROXY/HTTP_PROXY/NO_PROXY.
+  # SMTP is not sent through this proxy; see README
+  proxy: "{{.HTTP.Proxy}}"
+  # Try requests up to this many times on a 429, 500, 502, 503, 504 or network
+  # error, waiting retry_base_delay (doubling, with jitter) up to retry_max_delay
+  # between attempts (1 = no retries). AI requests are not retried here; see
+  # ai.rate_limit_retries and processing.video_retries
+  retry_attempts: {{.HTTP.RetryAttempts}}
+  retry_base_delay: "{{.HTTP.RetryBaseDelay}}"
+  retry_max_delay: "{{.HTTP.RetryMaxDelay}}"
+`
+
+// envScaffold is the .env template written by -init
+const envScaffold = `# YouTube Summarizer Environment Variables
+# Fill in your API keys; this file holds secrets, so keep it out of version control
+
+# Required: YouTube Data API v3 key
+YOUTUBE_API_KEY=
+
+# Required: Claude API key for summarization
+CLAUDE_API_KEY=
+
+# Optional: OpenAI API key, needed when ai.providers includes openai
+OPENAI_API_KEY=
+
+# Optional: RapidAPI key for transcript fetching
+RAPID_API_KEY=
+
+# Optional: Email credentials for digest sending
+EMAIL_USERNAME=
+EMAIL_PASSWORD=
+
+# Optional: Notion integration token for -export-notion
+NOTION_API_KEY=
+`
@@ -9,7 +9,8 @@ import (
 
 // Logger implements the types.Logger interface using zap
 type Logger struct {
-	zap *zap.Logger
+	zap    *zap.Logger
+	config zap.Config
 }
 
 // New creates a new structured logger
@@ -35,7 +36,7 @@ func New(development bool) (*Logger, error) {
 		return nil, err
 	}
 
-	return &Logger{zap: zapLogger}, nil
+	return &Logger{zap: zapLogger, config: config}, nil
 }
 
 // NewWithFile creates a logger that also writes to a file
@@ -61,7 +62,7 @@ func NewWithFile(development bool, logFile string) (*Logger, error) {
 		return nil, err
 	}
 
-	return &Logger{zap: zapLogger}, nil
+	return &Logger{zap: zapLogger, config: config}, nil
 }
 
 // Info logs an info message with optional fields
@@ -111,7 +112,20 @@ func (l *Logger) parseFields(fields ...interface{}) []zap.Field {
 // WithFields creates a logger with preset fields
 func (l *Logger) WithFields(fields ...interface{}) types.Logger {
 	zapFields := l.parseFields(fields...)
-	return &Logger{zap: l.zap.With(zapFields...)}
+	return &Logger{zap: l.zap.With(zapFields...), config: l.config}
+}
+
+// Verbose returns a logger that emits debug messages even outside development
+// mode, for opt-in diagnostics that shouldn't turn on all debug output
+func (l *Logger) Verbose() types.Logger {
+	config := l.config
+	config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+
+	zapLogger, err := config.Build()
+	if err != nil {
+		return l
+	}
+	return &Logger{zap: zapLogger, config: config}
 }
 
 // Close closes the logger and flushes any remaining logs
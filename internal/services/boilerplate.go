@@ -0,0 +1,71 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// boilerplateFilter strips recurring sponsor reads, intros and outros from
+// transcripts so summaries describe the video's actual content
+type boilerplateFilter struct {
+	phrases []*regexp.Regexp
+	markers map[string]types.TranscriptMarkers
+}
+
+// newBoilerplateFilter compiles ai.strip_phrases and ai.channel_markers; it returns
+// nil when neither is configured
+func newBoilerplateFilter(cfg types.AIConfig) *boilerplateFilter {
+	if len(cfg.StripPhrases) == 0 && len(cfg.ChannelMarkers) == 0 {
+		return nil
+	}
+
+	filter := &boilerplateFilter{markers: cfg.ChannelMarkers}
+	for _, phrase := range cfg.StripPhrases {
+		if pattern := phrasePattern(phrase); pattern != nil {
+			filter.phrases = append(filter.phrases, pattern)
+		}
+	}
+	return filter
+}
+
+// phrasePattern matches a phrase case-insensitively, allowing any whitespace
+// (transcript segments are joined with spaces or newlines) between its words
+func phrasePattern(phrase string) *regexp.Regexp {
+	words := strings.Fields(phrase)
+	if len(words) == 0 {
+		return nil
+	}
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return regexp.MustCompile(`(?i)` + strings.Join(words, `\s+`))
+}
+
+// strip removes the channel's intro and outro, then every configured phrase.
+// Markers are looked up by channel ID, then by channel name; a marker that isn't
+// found leaves the transcript as it was.
+func (f *boilerplateFilter) strip(transcript string, video types.Video) string {
+	markers, ok := f.markers[video.ChannelID]
+	if !ok {
+		markers = f.markers[video.ChannelName]
+	}
+
+	if pattern := phrasePattern(markers.IntroEnd); pattern != nil {
+		if loc := pattern.FindStringIndex(transcript); loc != nil {
+			transcript = transcript[loc[1]:]
+		}
+	}
+	if pattern := phrasePattern(markers.OutroStart); pattern != nil {
+		// The outro is the last occurrence, so a mention early on isn't mistaken for it
+		if locs := pattern.FindAllStringIndex(transcript, -1); len(locs) > 0 {
+			transcript = transcript[:locs[len(locs)-1][0]]
+		}
+	}
+
+	for _, pattern := range f.phrases {
+		transcript = pattern.ReplaceAllString(transcript, "")
+	}
+	return strings.TrimSpace(transcript)
+}
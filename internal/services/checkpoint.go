@@ -0,0 +1,109 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// runCheckpoint records which channels the current run has finished in a small
+// JSON file, saved after each channel, so an interrupted run can continue with
+// -resume instead of fetching every channel again. Finished videos need no
+// checkpoint since they are already in the processed-video list.
+type runCheckpoint struct {
+	path string
+
+	mu    sync.Mutex
+	state checkpointState
+}
+
+// checkpointState is the checkpoint file's content
+type checkpointState struct {
+	StartedAt time.Time `json:"started_at"`
+	// Channels maps each finished channel ID to when it finished
+	Channels map[string]time.Time `json:"channels"`
+}
+
+// openCheckpoint starts a checkpoint at path; with resume, the channels finished
+// by an earlier interrupted run are loaded from it (a missing file means none)
+func openCheckpoint(path string, resume bool) (*runCheckpoint, error) {
+	cp := &runCheckpoint{
+		path:  path,
+		state: checkpointState{StartedAt: time.Now(), Channels: make(map[string]time.Time)},
+	}
+	if !resume {
+		return cp, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var state checkpointState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if state.Channels != nil {
+		cp.state = state
+	}
+	return cp, nil
+}
+
+// done reports whether a channel was finished by the checkpointed run
+func (cp *runCheckpoint) done(channelID string) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	_, ok := cp.state.Channels[channelID]
+	return ok
+}
+
+// finished returns how many channels the checkpoint has recorded
+func (cp *runCheckpoint) finished() int {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.state.Channels)
+}
+
+// markDone records a finished channel and saves the checkpoint, writing a temporary
+// file first so a crash mid-write can't leave a truncated checkpoint
+func (cp *runCheckpoint) markDone(channelID string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.state.Channels[channelID] = time.Now()
+	data, err := json.MarshalIndent(cp.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if dir := filepath.Dir(cp.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create checkpoint directory: %w", err)
+		}
+	}
+	tmp := cp.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, cp.path); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// clear removes the checkpoint once a run has finished every channel
+func (cp *runCheckpoint) clear() error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import "youtube-summarizer/pkg/types"
+
+// digestTemplate returns the digest template for the config: the compact,
+// image-free layout when email.show_thumbnails is off, the default otherwise
+func digestTemplate(config *types.Config) string {
+	if !config.Email.ShowThumbnails {
+		return compactEmailTemplate
+	}
+	return defaultEmailTemplate
+}
+
+// compactEmailTemplate is a text-forward digest without any images (no thumbnails
+// or header image) and only inline styles that every client supports
+const compactEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; line-height: 1.5; color: #1C1B1F; max-width: 700px; margin: 0 auto; padding: 16px;">
+    <div role="banner" style="border-bottom: 2px solid #630D5F; padding-bottom: 8px;">
+        <h1 style="font-size: 1.4em; margin: 0; color: #630D5F;">{{.Title}}</h1>
+        <p style="margin: 4px 0 0 0; color: #6B6470;">{{.Date}} · {{.TotalCount}} video summaries</p>
+        {{if .Intro}}<p style="margin: 8px 0 0 0; font-style: italic;">{{.Intro}}</p>{{end}}
+    </div>
+
+    <div role="main">
+        {{if .Groups}}
+        {{range .Groups}}
+        <h2 style="font-size: 1.2em; margin: 24px 0 0 0; color: #630D5F;">{{.Name}}</h2>
+        {{range .Summaries}}{{template "compactCard" card . true}}{{end}}
+        {{end}}
+        {{else}}
+        {{range .Summaries}}{{template "compactCard" card . false}}{{end}}
+        {{end}}
+
+        {{range .Overflow}}
+        <p style="margin: 16px 0 0 0; font-size: 0.9em;">
+            <strong>+{{len .Summaries}} more from {{.ChannelName}}:</strong>
+            {{range $i, $s := .Summaries}}{{if $i}} · {{end}}<a href="{{$s.VideoURL}}" style="color: #630D5F;">{{$s.VideoTitle}}</a>{{end}}
+        </p>
+        {{end}}
+    </div>
+
+    <p role="contentinfo" style="margin: 24px 0 0 0; padding-top: 8px; border-top: 1px solid #DDD6E0; color: #6B6470; font-size: 0.85em;">Powered by Claude AI</p>
+</body>
+</html>{{define "compactCard"}}
+<div role="article" aria-label="{{.VideoTitle}}" style="margin: 18px 0 0 0;">
+    {{if .Nested}}<h3 style="font-size: 1.05em; margin: 0;">{{else}}<h2 style="font-size: 1.05em; margin: 0;">{{end}}<a href="{{.VideoURL}}" style="color: #1C1B1F;">{{.VideoTitle}}</a>{{if .Nested}}</h3>{{else}}</h2>{{end}}
+    <p style="margin: 2px 0 6px 0; color: #6B6470; font-size: 0.85em;">
+        {{.ChannelName}} · {{.PublishedAt.Format "Jan 2, 2006"}}{{with duration .Duration}} · {{.}}{{end}}{{if gt .ReadingMinutes 0}} · {{.ReadingMinutes}} min read{{end}}{{if eq .Source "description"}} · from description{{end}}{{with foreignLang .TranscriptLang}} · {{.}}{{end}}
+    </p>
+    {{with qa .Summary}}
+    <dl style="margin: 0;">
+        {{range .}}
+        <dt style="font-weight: 600; margin-top: 6px;">{{.Question}}</dt>
+        <dd style="margin: 2px 0 0 0;">{{.Answer}}</dd>
+        {{end}}
+    </dl>
+    {{else}}
+    <div>{{summaryBody (clip .Summary)}}{{if clipped .Summary}} <a href="{{.VideoURL}}" aria-label="Read more: {{.VideoTitle}}" style="color: #630D5F;">… (read more)</a>{{end}}</div>
+    {{end}}
+    {{range .Quotes}}
+    <blockquote style="margin: 6px 0 0 0; padding-left: 10px; border-left: 3px solid #BFA359; font-style: italic;">“{{.}}”</blockquote>
+    {{end}}
+</div>
+{{end}}`
@@ -2,8 +2,14 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"net/mail"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +29,9 @@ type EmailService struct {
 
 	// Template for email content
 	emailTemplate *template.Template
+
+	// Optional AI client used to write the digest intro
+	aiClient types.AIClient
 }
 
 // NewEmailService creates a new email service
@@ -32,8 +41,16 @@ func NewEmailService(
 	logger types.Logger,
 ) (*EmailService, error) {
 
+	if config.Email.Auth == "none" {
+		if username == "" && len(config.Email.Recipients) == 0 {
+			return nil, fmt.Errorf("email.auth none needs EMAIL_USERNAME or email.recipients for the sender address")
+		}
+	} else if username == "" || password == "" {
+		return nil, fmt.Errorf("EMAIL_USERNAME and EMAIL_PASSWORD are required unless email.auth is none")
+	}
+
 	// Create email template
-	tmpl, err := template.New("email").Parse(defaultEmailTemplate)
+	tmpl, err := template.New("email").Funcs(emailTemplateFuncs(config)).Parse(digestTemplate(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse email template: %w", err)
 	}
@@ -47,31 +64,286 @@ func NewEmailService(
 	}, nil
 }
 
+// templateFuncs are the helper functions available to email templates
+var templateFuncs = template.FuncMap{
+	"duration":    types.HumanizeDuration,
+	"qa":          parseQA,
+	"thumbSrc":    thumbnailSrc,
+	"summaryBody": plainSummary,
+	"foreignLang": foreignLang,
+	"card":        newDigestCard,
+}
+
+// cardSummary is embedded under its own name so that .Summary in the card
+// template is still the summary text
+type cardSummary = types.Summary
+
+// digestCard is one summary card in the digest; Nested cards sit under a group
+// heading, so their title is one heading level lower
+type digestCard struct {
+	cardSummary
+	Nested bool
+}
+
+// newDigestCard wraps a summary for the card template
+func newDigestCard(summary types.Summary, nested bool) digestCard {
+	return digestCard{cardSummary: summary, Nested: nested}
+}
+
+// foreignLang returns a transcript language code worth flagging in the email:
+// upper-cased when it isn't English, empty otherwise
+func foreignLang(lang string) string {
+	if lang == "" || strings.EqualFold(lang, "en") || strings.HasPrefix(strings.ToLower(lang), "en-") {
+		return ""
+	}
+	return strings.ToUpper(lang)
+}
+
+// QAPair is a single answered question from a questions-mode summary
+type QAPair struct {
+	Question string
+	Answer   string
+}
+
+// parseQA splits a questions-mode summary into its "Q:"/"A:" pairs; it
+// returns nil for regular summaries so they render as plain text
+func parseQA(summary string) []QAPair {
+	var pairs []QAPair
+	var current *QAPair
+	for _, line := range strings.Split(summary, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Q:"):
+			pairs = append(pairs, QAPair{Question: strings.TrimSpace(strings.TrimPrefix(line, "Q:"))})
+			current = &pairs[len(pairs)-1]
+		case strings.HasPrefix(line, "A:") && current != nil:
+			current.Answer = strings.TrimSpace(strings.TrimPrefix(line, "A:"))
+		case line != "" && current != nil && current.Answer != "":
+			current.Answer += " " + line
+		}
+	}
+	return pairs
+}
+
+// digestIntroPrompt asks for a short overview of the digest; {transcript} receives the summaries
+const digestIntroPrompt = `Below are summaries of the YouTube videos in today's digest.
+
+{transcript}
+
+Write a 1-2 sentence overview that ties together the main themes across these videos, starting with "Today's themes:". Respond with the overview only.`
+
 // EmailData represents the data passed to the email template
 type EmailData struct {
-	Date       string
-	Summaries  []types.Summary
-	TotalCount int
+	Title          string
+	HeaderImageURL string
+	Date           string
+	Intro          string
+	Summaries      []types.Summary
+	TotalCount     int
+	// Overflow lists summaries left out by email.max_per_channel, per channel
+	Overflow []ChannelGroup
+	// Groups sections the summaries by channel group (email.group_by: group)
+	Groups []DigestGroup
 }
 
-// SendDigest sends an email digest with the provided summaries
+// DigestGroup is one channel group's section of the digest
+type DigestGroup struct {
+	Name      string
+	Summaries []types.Summary
+}
+
+// ungroupedName heads the section of summaries whose channel has no group
+const ungroupedName = "Other"
+
+// DigestError is returned by SendDigest when some of its digests failed to send.
+// Sent holds the summaries of the digests that did go out, so they can still be
+// marked processed while the rest stay pending.
+type DigestError struct {
+	Sent []types.Summary
+	Err  error
+}
+
+func (e *DigestError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DigestError) Unwrap() error {
+	return e.Err
+}
+
+// SentSummaries returns the summaries that were sent despite a SendDigest error
+func SentSummaries(err error) []types.Summary {
+	var digestErr *DigestError
+	if errors.As(err, &digestErr) {
+		return digestErr.Sent
+	}
+	return nil
+}
+
+// SendDigest sends an email digest with the provided summaries. Groups in
+// email.group_recipients and groups or channels in email.routes are sent as
+// separate digests to their own recipients; the rest go to email.recipients.
+// A digest that fails doesn't stop the others; the error is a *DigestError
+// listing the summaries that were sent.
 func (es *EmailService) SendDigest(ctx context.Context, summaries []types.Summary) error {
 	if len(summaries) == 0 {
 		es.logger.Info("No summaries to send, skipping email digest")
 		return nil
 	}
 
-	es.logger.Info("Preparing to send email digest", "summaryCount", len(summaries))
+	remaining, routed := splitRoutes(summaries, es.config.Email.GroupRecipients, es.config.Email.Routes)
+	if len(remaining) > 0 {
+		routed = append(routed, digestRoute{recipients: es.config.Email.Recipients, summaries: remaining})
+	}
+
+	var sent []types.Summary
+	var errs []error
+	for _, route := range routed {
+		if err := es.sendDigest(ctx, route.summaries, route.recipients, route.name); err != nil {
+			if route.name != "" {
+				err = fmt.Errorf("failed to send %s digest: %w", route.name, err)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		sent = append(sent, route.summaries...)
+	}
+
+	if len(errs) > 0 {
+		return &DigestError{Sent: sent, Err: errors.Join(errs...)}
+	}
+	return nil
+}
+
+// digestRoute is a separate digest for the summaries routed to its recipients
+type digestRoute struct {
+	name       string
+	recipients []string
+	summaries  []types.Summary
+}
+
+// splitRoutes separates the summaries whose channel ID (in routes) or group (in
+// routes or groupRecipients) has its own recipients, returning the rest for the
+// main digest. Routes are sorted by name, which is the group or channel name.
+func splitRoutes(summaries []types.Summary, groupRecipients, routes map[string][]string) ([]types.Summary, []digestRoute) {
+	if len(groupRecipients) == 0 && len(routes) == 0 {
+		return summaries, nil
+	}
+
+	var remaining []types.Summary
+	byKey := make(map[string]*digestRoute)
+	var keys []string
+	for _, summary := range summaries {
+		key, name, recipients := "", "", []string(nil)
+		switch {
+		case summary.ChannelID != "" && len(routes[summary.ChannelID]) > 0:
+			key, name, recipients = summary.ChannelID, summary.ChannelName, routes[summary.ChannelID]
+		case summary.Group != "" && len(routes[summary.Group]) > 0:
+			key, name, recipients = summary.Group, summary.Group, routes[summary.Group]
+		case summary.Group != "" && len(groupRecipients[summary.Group]) > 0:
+			key, name, recipients = summary.Group, summary.Group, groupRecipients[summary.Group]
+		default:
+			remaining = append(remaining, summary)
+			continue
+		}
+
+		route, ok := byKey[key]
+		if !ok {
+			route = &digestRoute{name: name, recipients: recipients}
+			byKey[key] = route
+			keys = append(keys, key)
+		}
+		route.summaries = append(route.summaries, summary)
+	}
+
+	routed := make([]digestRoute, 0, len(keys))
+	for _, key := range keys {
+		routed = append(routed, *byKey[key])
+	}
+	sort.SliceStable(routed, func(i, j int) bool {
+		return strings.ToLower(routed[i].name) < strings.ToLower(routed[j].name)
+	})
+	return remaining, routed
+}
+
+// groupByLabel groups summaries by channel group, sorted by name with ungrouped
+// summaries last; summaries keep their order within a group
+func groupByLabel(summaries []types.Summary) []DigestGroup {
+	indexByGroup := make(map[string]int)
+	var groups []DigestGroup
+	for _, summary := range summaries {
+		i, ok := indexByGroup[summary.Group]
+		if !ok {
+			i = len(groups)
+			indexByGroup[summary.Group] = i
+			groups = append(groups, DigestGroup{Name: summary.Group})
+		}
+		groups[i].Summaries = append(groups[i].Summaries, summary)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Name == "" || groups[j].Name == "" {
+			return groups[j].Name == ""
+		}
+		return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+	})
+	for i := range groups {
+		if groups[i].Name == "" {
+			groups[i].Name = ungroupedName
+		}
+	}
+	return groups
+}
+
+// sendDigest renders and sends one digest to recipients; a non-empty group (a group
+// or channel name) is added to the title of a routed digest
+func (es *EmailService) sendDigest(ctx context.Context, summaries []types.Summary, recipients []string, group string) error {
+	es.logger.Info("Preparing to send email digest", "summaryCount", len(summaries), "group", group)
+
+	title := es.config.Email.DigestTitle
+	if group != "" {
+		title = fmt.Sprintf("%s: %s", title, group)
+	}
 
 	// Prepare email data
 	emailData := EmailData{
-		Date:       time.Now().Format("January 2, 2006"),
-		Summaries:  summaries,
-		TotalCount: len(summaries),
+		Title:          title,
+		HeaderImageURL: es.config.Email.HeaderImageURL,
+		Date:           time.Now().Format(es.config.Email.DateFormat),
+		Summaries:      summaries,
+		TotalCount:     len(summaries),
+	}
+
+	// Optionally write an AI overview of the digest (costs one extra AI call)
+	if es.config.Email.IncludeIntro {
+		intro, err := es.generateIntro(ctx, summaries)
+		if err != nil {
+			es.logger.Warn("Failed to generate digest intro, sending without it", "error", err)
+		} else {
+			emailData.Intro = intro
+		}
+	}
+
+	// Keep one busy channel from dominating the digest
+	if limit := es.config.Email.MaxPerChannel; limit > 0 {
+		emailData.Summaries, emailData.Overflow = capPerChannel(summaries, limit)
+	}
+
+	// Optionally attach thumbnails inline so they show without loading remote images
+	var images []embeddedImage
+	if es.config.Email.EmbedThumbnails {
+		emailData.Summaries, images = es.embedThumbnails(ctx, emailData.Summaries)
+	}
+
+	// Section the digest by channel group when there is more than one
+	if es.config.Email.GroupBy == "group" {
+		if groups := groupByLabel(emailData.Summaries); len(groups) > 1 {
+			emailData.Groups = groups
+		}
 	}
 
 	// Debug: Log thumbnail URLs being passed to template
-	for i, summary := range summaries {
+	for i, summary := range emailData.Summaries {
 		es.logger.Debug("Email template data", "index", i, "videoTitle", summary.VideoTitle, "thumbnailURL", summary.ThumbnailURL)
 	}
 
@@ -81,19 +353,86 @@ func (es *EmailService) SendDigest(ctx context.Context, summaries []types.Summar
 		return fmt.Errorf("failed to generate email content: %w", err)
 	}
 
+	var text string
+	if es.config.Email.PlainText {
+		text = plainTextDigest(emailData)
+	}
+
+	// Attach a PDF copy for saving; a failed conversion only drops the attachment
+	var attachments []embeddedImage
+	if es.config.Email.AttachPDF {
+		pdf, err := es.renderPDF(ctx, body)
+		if err != nil {
+			es.logger.Warn("Failed to render digest PDF, sending without it", "error", err)
+		} else {
+			name := fmt.Sprintf("youtube-digest-%s.pdf", time.Now().Format("2006-01-02"))
+			attachments = append(attachments, embeddedImage{name: name, data: pdf})
+		}
+	}
+
 	// Send the email
-	if err := es.sendEmail(subject, body); err != nil {
+	if err := es.sendEmail(recipients, subject, body, text, attachments, images...); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
-	es.logger.Info("Successfully sent email digest", "summaryCount", len(summaries))
+	es.logger.Info("Successfully sent email digest", "summaryCount", len(summaries), "group", group)
 	return nil
 }
 
+// capPerChannel keeps the newest limit summaries of each channel in their original
+// order and returns the rest grouped by channel
+func capPerChannel(summaries []types.Summary, limit int) ([]types.Summary, []ChannelGroup) {
+	keep := make(map[string]bool)
+	for _, group := range groupByChannel(summaries) {
+		for i, summary := range group.Summaries {
+			if i < limit {
+				keep[summary.ID] = true
+			}
+		}
+	}
+
+	var shown []types.Summary
+	var hidden []types.Summary
+	for _, summary := range summaries {
+		if keep[summary.ID] {
+			shown = append(shown, summary)
+		} else {
+			hidden = append(hidden, summary)
+		}
+	}
+
+	if len(hidden) == 0 {
+		return shown, nil
+	}
+	return shown, groupByChannel(hidden)
+}
+
+// generateIntro makes one AI call over all summaries to produce a short lede for the digest
+func (es *EmailService) generateIntro(ctx context.Context, summaries []types.Summary) (string, error) {
+	if es.aiClient == nil {
+		return "", fmt.Errorf("no AI client configured for digest intro")
+	}
+
+	var combined strings.Builder
+	for _, summary := range summaries {
+		fmt.Fprintf(&combined, "%s (%s): %s\n\n", summary.VideoTitle, summary.ChannelName, summary.Summary)
+	}
+
+	return es.aiClient.SummarizeWithPrompt(ctx, digestIntroPrompt, combined.String(), "Digest intro")
+}
+
 // generateEmailContent creates the subject and body for the digest email
 func (es *EmailService) generateEmailContent(data EmailData) (string, string, error) {
 	// Generate subject
-	subject := strings.ReplaceAll(es.config.Email.SubjectTemplate, "{date}", data.Date)
+	channels := make(map[string]struct{})
+	for _, summary := range data.Summaries {
+		channels[summary.ChannelName] = struct{}{}
+	}
+	subject := strings.NewReplacer(
+		"{date}", data.Date,
+		"{count}", strconv.Itoa(data.TotalCount),
+		"{channels}", strconv.Itoa(len(channels)),
+	).Replace(es.config.Email.SubjectTemplate)
 
 	// Generate body using template
 	var body strings.Builder
@@ -104,24 +443,124 @@ func (es *EmailService) generateEmailContent(data EmailData) (string, string, er
 	return subject, body.String(), nil
 }
 
-// sendEmail sends an email using SMTP
-func (es *EmailService) sendEmail(subject, body string) error {
-	m := gomail.NewMessage()
+// plainTextDigest renders the digest as plain text, the alternative to the HTML body
+// used by screen readers and text-only clients
+func plainTextDigest(data EmailData) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n%s\n\n", data.Title, data.Date)
+	if data.Intro != "" {
+		fmt.Fprintf(&text, "%s\n\n", data.Intro)
+	}
+	fmt.Fprintf(&text, "%d video summaries\n", data.TotalCount)
+
+	groups := data.Groups
+	if len(groups) == 0 {
+		groups = []DigestGroup{{Summaries: data.Summaries}}
+	}
+	n := 0
+	for _, group := range groups {
+		if group.Name != "" {
+			fmt.Fprintf(&text, "\n== %s ==\n", group.Name)
+		}
+		for _, summary := range group.Summaries {
+			n++
+			fmt.Fprintf(&text, "\n%d. %s\n", n, summary.VideoTitle)
+			fmt.Fprintf(&text, "%s, published %s", summary.ChannelName, summary.PublishedAt.Format("Jan 2, 2006"))
+			if duration := types.HumanizeDuration(summary.Duration); duration != "" {
+				fmt.Fprintf(&text, " (%s)", duration)
+			}
+			text.WriteString("\n\n")
+			fmt.Fprintf(&text, "%s\n\n", strings.TrimSpace(summary.Summary))
+			for _, quote := range summary.Quotes {
+				fmt.Fprintf(&text, "> \"%s\"\n", quote)
+			}
+			if len(summary.Quotes) > 0 {
+				text.WriteString("\n")
+			}
+			fmt.Fprintf(&text, "Watch: %s\n", summary.VideoURL)
+		}
+	}
+
+	for _, group := range data.Overflow {
+		fmt.Fprintf(&text, "\n+%d more from %s:\n", len(group.Summaries), group.ChannelName)
+		for _, summary := range group.Summaries {
+			fmt.Fprintf(&text, "- %s: %s\n", summary.VideoTitle, summary.VideoURL)
+		}
+	}
+	return text.String()
+}
+
+// sendEmail sends an email using SMTP to recipients (empty sends it to the sender).
+// A non-empty text is sent as the plain-text alternative to the HTML body.
+func (es *EmailService) sendEmail(recipients []string, subject, body, text string, attachments []embeddedImage, images ...embeddedImage) error {
+	m := gomail.NewMessage(
+		gomail.SetCharset("UTF-8"),
+		gomail.SetEncoding(gomail.Encoding(es.config.Email.TransferEncoding)),
+	)
 
 	// Set headers
-	m.SetHeader("From", es.username)
-	m.SetHeader("To", es.username) // Send to self for now
+	sender := es.sender()
+	to, bcc := resolveRecipients(recipients, sender, es.config.Email.SendToSelf)
+	bcc = append(bcc, es.config.Email.BCC...)
+	cc := es.config.Email.CC
+
+	// A bad address would otherwise only fail partway through the SMTP exchange
+	for _, addresses := range [][]string{to, cc, bcc} {
+		if err := checkAddresses(addresses); err != nil {
+			return err
+		}
+	}
+
+	m.SetHeader("From", sender)
+	m.SetHeader("To", to...)
+	if len(cc) > 0 {
+		m.SetHeader("Cc", cc...)
+	}
+	if len(bcc) > 0 {
+		m.SetHeader("Bcc", bcc...)
+	}
 	m.SetHeader("Subject", subject)
+	m.SetHeader("MIME-Version", "1.0")
+	if es.config.Email.ListUnsubscribe != "" {
+		m.SetHeader("List-Unsubscribe", "<"+es.config.Email.ListUnsubscribe+">")
+	}
 
-	// Set body
-	m.SetBody("text/html", body)
+	// Set body; clients show the last alternative they support, so HTML goes last
+	if text != "" {
+		m.SetBody("text/plain", text)
+		m.AddAlternative("text/html", body)
+	} else {
+		m.SetBody("text/html", body)
+	}
+
+	// Attach inline images referenced from the body as cid:<name>
+	for _, image := range images {
+		data := image.data
+		m.Embed(image.name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}))
+	}
 
-	// Create dialer
+	// Attach files such as the PDF digest
+	for _, attachment := range attachments {
+		data := attachment.data
+		m.Attach(attachment.name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}))
+	}
+
+	// Create dialer; gomail skips SMTP AUTH when the username is empty
+	username, password := es.username, es.password
+	if es.config.Email.Auth == "none" {
+		username, password = "", ""
+	}
 	d := gomail.NewDialer(
 		es.config.Email.SMTPHost,
 		es.config.Email.SMTPPort,
-		es.username,
-		es.password,
+		username,
+		password,
 	)
 
 	// Send the email
@@ -132,6 +571,38 @@ func (es *EmailService) sendEmail(subject, body string) error {
 	return nil
 }
 
+// checkAddresses returns an error naming the first address that can't be parsed
+func checkAddresses(addresses []string) error {
+	for _, address := range addresses {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("invalid email address %q: %w", address, err)
+		}
+	}
+	return nil
+}
+
+// sender returns the From address: EMAIL_USERNAME, or with email.auth none and no
+// username, the first recipient
+func (es *EmailService) sender() string {
+	if es.username == "" && len(es.config.Email.Recipients) > 0 {
+		return es.config.Email.Recipients[0]
+	}
+	return es.username
+}
+
+// resolveRecipients decides who gets the email: explicit recipients win, otherwise
+// the sender gets it. With sendToSelf the sender is BCCed when not already a recipient.
+func resolveRecipients(recipients []string, sender string, sendToSelf bool) (to, bcc []string) {
+	if len(recipients) == 0 {
+		return []string{sender}, nil
+	}
+
+	if sendToSelf && !slices.ContainsFunc(recipients, func(r string) bool { return strings.EqualFold(r, sender) }) {
+		bcc = []string{sender}
+	}
+	return recipients, bcc
+}
+
 // SendTestEmail sends a test email to verify configuration
 func (es *EmailService) SendTestEmail(ctx context.Context) error {
 	es.logger.Info("Sending test email")
@@ -157,7 +628,7 @@ func (es *EmailService) SendTestEmail(ctx context.Context) error {
 
 // SetEmailTemplate allows custom email templates
 func (es *EmailService) SetEmailTemplate(templateStr string) error {
-	tmpl, err := template.New("email").Parse(templateStr)
+	tmpl, err := template.New("email").Funcs(emailTemplateFuncs(es.config)).Parse(templateStr)
 	if err != nil {
 		return fmt.Errorf("failed to parse email template: %w", err)
 	}
@@ -167,9 +638,14 @@ func (es *EmailService) SetEmailTemplate(templateStr string) error {
 	return nil
 }
 
+// SetAIClient sets the AI client used to write the optional digest intro
+func (es *EmailService) SetAIClient(aiClient types.AIClient) {
+	es.aiClient = aiClient
+}
+
 // GetEmailTemplate returns the current email template
 func (es *EmailService) GetEmailTemplate() string {
-	return defaultEmailTemplate
+	return digestTemplate(es.config)
 }
 
 // Default email template with Royal color palette
@@ -178,7 +654,7 @@ const defaultEmailTemplate = `<!DOCTYPE html>
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>YouTube Summary Digest</title>
+    <title>{{.Title}}</title>
     <style>
         body {
             font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif;
@@ -203,6 +679,12 @@ const defaultEmailTemplate = `<!DOCTYPE html>
             padding: 40px 30px;
             margin-bottom: 0;
         }
+        .header-image {
+            display: block;
+            max-width: 100%;
+            max-height: 120px;
+            margin: 0 auto 20px auto;
+        }
         .header h1 {
             margin: 0;
             font-size: 2.8em;
@@ -215,6 +697,13 @@ const defaultEmailTemplate = `<!DOCTYPE html>
             opacity: 0.9;
             font-weight: 300;
         }
+        .header .intro {
+            margin: 20px auto 0 auto;
+            max-width: 640px;
+            font-size: 1.05em;
+            font-style: italic;
+            opacity: 0.95;
+        }
         .stats {
             background: linear-gradient(135deg, #BFA359 0%, #FEFFC4 100%);
             color: #1C1B1F;
@@ -227,6 +716,21 @@ const defaultEmailTemplate = `<!DOCTYPE html>
         .content-area {
             padding: 30px;
         }
+        .group-heading {
+            margin: 10px 0 20px 0;
+            padding-bottom: 8px;
+            color: #630D5F;
+            border-bottom: 2px solid #BFA359;
+        }
+        .overflow-note {
+            border-left: 5px solid #B37BA4;
+            padding: 10px 20px;
+            margin-bottom: 15px;
+            color: #1C1B1F;
+        }
+        .overflow-note a {
+            color: #630D5F;
+        }
         .video-card {
             background: linear-gradient(135deg, #FEFFC4 0%, #F6F3EB 100%);
             border: 2px solid #B37BA4;
@@ -302,6 +806,12 @@ const defaultEmailTemplate = `<!DOCTYPE html>
             border-radius: 20px;
             font-weight: 500;
         }
+        .source-badge {
+            font-size: 0.85em;
+        }
+        .source-weak {
+            background: rgba(191, 163, 89, 0.25);
+        }
         .channel-name {
             color: #B37BA4;
             font-weight: 600;
@@ -316,6 +826,30 @@ const defaultEmailTemplate = `<!DOCTYPE html>
             line-height: 1.7;
             font-size: 1.05em;
         }
+        .summary-content > :first-child {
+            margin-top: 0;
+        }
+        .summary-content > :last-child {
+            margin-bottom: 0;
+        }
+        .read-more {
+            color: #BFA359;
+            font-weight: 600;
+            text-decoration: none;
+        }
+        .qa-list dt {
+            font-weight: 600;
+            margin-top: 10px;
+        }
+        .qa-list dd {
+            margin: 4px 0 0 0;
+        }
+        .quote {
+            margin: 12px 0 0 0;
+            padding: 4px 0 4px 14px;
+            border-left: 3px solid #BFA359;
+            font-style: italic;
+        }
         .video-actions {
             padding: 0 25px 25px 25px;
             display: flex;
@@ -390,69 +924,120 @@ const defaultEmailTemplate = `<!DOCTYPE html>
 </head>
 <body>
     <div class="container">
-        <div class="header">
-            <h1>YouTube Video Digest</h1>
+        <div class="header" role="banner">
+            {{if .HeaderImageURL}}<img class="header-image" src="{{.HeaderImageURL}}" alt="{{.Title}} logo">{{end}}
+            <h1>{{.Title}}</h1>
             <p>{{.Date}}</p>
+            {{if .Intro}}<p class="intro">{{.Intro}}</p>{{end}}
         </div>
 
         <div class="stats">
-            🎬 {{.TotalCount}} video summaries curated for you
+            <span aria-hidden="true">🎬</span> {{.TotalCount}} video summaries curated for you
         </div>
 
-        <div class="content-area">
-            {{range .Summaries}}
-            <div class="video-card">
-                <div class="video-header" style="display: flex; align-items: flex-start; padding: 25px; gap: 20px;">
-                    <div class="thumbnail-container" style="flex-shrink: 0; position: relative;">
-                        <img src="{{.ThumbnailURL}}" alt="{{.VideoTitle}} thumbnail" class="thumbnail" 
-                             style="width: 180px; height: 101px; border-radius: 12px; object-fit: cover; border: 3px solid #630D5F; display: block; max-width: 180px; max-height: 101px;"
-                             onerror="this.style.display='none'; this.nextElementSibling.style.display='block';" />
-                        <!-- Fallback for when image fails to load -->
-                        <div style="display: none; width: 180px; height: 101px; border-radius: 12px; border: 3px solid #630D5F; background: linear-gradient(135deg, #630D5F, #B37BA4); color: #FEFFC4; align-items: center; justify-content: center; text-align: center; font-size: 12px; font-weight: bold; padding: 10px; box-sizing: border-box;">
-                            📺 Video<br/>Thumbnail
-                        </div>
-                        {{if .Duration}}<div class="duration-badge">{{.Duration}}</div>{{end}}
-                    </div>
-                    <div class="video-info" style="flex: 1; min-width: 0;">
-                        <h3 class="video-title">{{.VideoTitle}}</h3>
-                        <div class="video-meta">
-                            <div class="meta-item">
-                                <span style="margin-right: 5px;">📺</span>
-                                <span class="channel-name">{{.ChannelName}}</span>
-                            </div>
-                            {{if gt .ViewCount 0}}
-                            <div class="meta-item">
-                                <span>👁</span>
-                                <span>{{.ViewCount}} views</span>
-                            </div>
-                            {{end}}
-                        </div>
-                    </div>
-                </div>
-                
-                <div class="summary-content">
-                    {{.Summary}}
-                </div>
-                
-                <div class="video-actions">
-                    <div class="published-date">
-                        <span style="margin-right: 5px;">📅</span>
-                        <span>Published {{.PublishedAt.Format "Jan 2, 2006"}}</span>
-                    </div>
-                </div>
-                <div class="video-actions">
-                    <a href="{{.VideoURL}}" class="watch-button">
-                        <span>Watch Video</span>
-                    </a>
-                </div>
+        <div class="content-area" role="main">
+            {{if .Groups}}
+            {{range .Groups}}
+            <h2 class="group-heading">{{.Name}}</h2>
+            {{range .Summaries}}{{template "card" card . true}}{{end}}
+            {{end}}
+            {{else}}
+            {{range .Summaries}}{{template "card" card . false}}{{end}}
+            {{end}}
+
+            {{range .Overflow}}
+            <div class="overflow-note">
+                <strong>+{{len .Summaries}} more from {{.ChannelName}}:</strong>
+                {{range $i, $s := .Summaries}}{{if $i}} · {{end}}<a href="{{$s.VideoURL}}">{{$s.VideoTitle}}</a>{{end}}
             </div>
             {{end}}
         </div>
 
-        <div class="footer">
+        <div class="footer" role="contentinfo">
             <p class="main-text">Generated for Geronimo Rodriguez</p>
             <p class="sub-text">🤖 Powered by Claude AI • Built with Go • Designed by Keryn Suoress</p>
         </div>
     </div>
 </body>
-</html>`
+</html>{{define "card"}}
+<div class="video-card" role="article" aria-label="{{.VideoTitle}}">
+    <div class="video-header" style="display: flex; align-items: flex-start; padding: 25px; gap: 20px;">
+        <div class="thumbnail-container" style="flex-shrink: 0; position: relative;">
+            <img src="{{thumbSrc .ThumbnailURL}}" alt="Thumbnail of the video &quot;{{.VideoTitle}}&quot; by {{.ChannelName}}" class="thumbnail" 
+                 style="width: 180px; height: 101px; border-radius: 12px; object-fit: cover; border: 3px solid #630D5F; display: block; max-width: 180px; max-height: 101px;"
+                 onerror="this.style.display='none'; this.nextElementSibling.style.display='block';" />
+            <!-- Fallback for when image fails to load -->
+            <div aria-hidden="true" style="display: none; width: 180px; height: 101px; border-radius: 12px; border: 3px solid #630D5F; background: linear-gradient(135deg, #630D5F, #B37BA4); color: #FEFFC4; align-items: center; justify-content: center; text-align: center; font-size: 12px; font-weight: bold; padding: 10px; box-sizing: border-box;">
+                📺 Video<br/>Thumbnail
+            </div>
+            {{with duration .Duration}}<div class="duration-badge" aria-label="Duration {{.}}">{{.}}</div>{{end}}
+        </div>
+        <div class="video-info" style="flex: 1; min-width: 0;">
+            {{if .Nested}}<h3 class="video-title">{{.VideoTitle}}</h3>{{else}}<h2 class="video-title">{{.VideoTitle}}</h2>{{end}}
+            <div class="video-meta">
+                <div class="meta-item">
+                    <span style="margin-right: 5px;" aria-hidden="true">📺</span>
+                    <span class="channel-name">{{.ChannelName}}</span>
+                </div>
+                {{if gt .ViewCount 0}}
+                <div class="meta-item">
+                    <span aria-hidden="true">👁</span>
+                    <span>{{.ViewCount}} views</span>
+                </div>
+                {{end}}
+                {{if gt .WordCount 0}}
+                <div class="meta-item">
+                    <span aria-hidden="true">📝</span>
+                    <span>~{{.WordCount}} words</span>
+                </div>
+                {{end}}
+                {{if gt .ReadingMinutes 0}}
+                <div class="meta-item">
+                    <span aria-hidden="true">⏱</span>
+                    <span>{{.ReadingMinutes}} min read</span>
+                </div>
+                {{end}}
+                {{if eq .Source "transcript"}}
+                <div class="meta-item source-badge">✓ Transcript</div>
+                {{else if eq .Source "alt_transcript"}}
+                <div class="meta-item source-badge">Alt. transcript</div>
+                {{else if eq .Source "description"}}
+                <div class="meta-item source-badge source-weak">⚠ From description</div>
+                {{end}}
+                {{with foreignLang .TranscriptLang}}
+                <div class="meta-item source-badge">🌐 {{.}}</div>
+                {{end}}
+            </div>
+        </div>
+    </div>
+    
+    <div class="summary-content">
+        {{with qa .Summary}}
+        <dl class="qa-list">
+            {{range .}}
+            <dt>{{.Question}}</dt>
+            <dd>{{.Answer}}</dd>
+            {{end}}
+        </dl>
+        {{else}}
+        {{summaryBody (clip .Summary)}}
+        {{if clipped .Summary}}<a href="{{.VideoURL}}" class="read-more" aria-label="Read more: {{.VideoTitle}}">… (read more)</a>{{end}}
+        {{end}}
+        {{range .Quotes}}
+        <blockquote class="quote">“{{.}}”</blockquote>
+        {{end}}
+    </div>
+    
+    <div class="video-actions">
+        <div class="published-date">
+            <span style="margin-right: 5px;" aria-hidden="true">📅</span>
+            <span>Published {{.PublishedAt.Format "Jan 2, 2006"}}</span>
+        </div>
+    </div>
+    <div class="video-actions">
+        <a href="{{.VideoURL}}" class="watch-button" aria-label="Watch {{.VideoTitle}} on YouTube">
+            <span>Watch Video</span>
+        </a>
+    </div>
+</div>
+{{end}}`
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"youtube-summarizer/internal/config"
+	"youtube-summarizer/pkg/types"
+)
+
+func TestPlainTextDigest(t *testing.T) {
+	published := time.Date(2025, time.March, 4, 10, 0, 0, 0, time.UTC)
+	data := EmailData{
+		Title:      "YouTube Video Digest",
+		Date:       "March 5, 2025",
+		TotalCount: 2,
+		Summaries: []types.Summary{
+			{
+				VideoTitle:   "With details",
+				ChannelName:  "Channel A",
+				Summary:      "First summary.",
+				VideoURL:     "https://www.youtube.com/watch?v=a",
+				PublishedAt:  published,
+				ThumbnailURL: "https://i.ytimg.com/vi/a/hqdefault.jpg",
+				Duration:     "PT1H2M3S",
+			},
+			{
+				VideoTitle:  "Without details",
+				ChannelName: "Channel B",
+				Summary:     "  Second summary.  ",
+				VideoURL:    "https://www.youtube.com/watch?v=b",
+				PublishedAt: published,
+			},
+		},
+	}
+
+	text := plainTextDigest(data)
+
+	for _, want := range []string{
+		"YouTube Video Digest\nMarch 5, 2025\n",
+		"2 video summaries\n",
+		"1. With details\nChannel A, published Mar 4, 2025 (1:02:03)\n\nFirst summary.\n\nWatch: https://www.youtube.com/watch?v=a\n",
+		"2. Without details\nChannel B, published Mar 4, 2025\n\nSecond summary.\n\nWatch: https://www.youtube.com/watch?v=b\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("plain text digest is missing %q:\n%s", want, text)
+		}
+	}
+
+	if strings.Contains(text, "()") {
+		t.Errorf("plain text digest shows an empty duration:\n%s", text)
+	}
+	if strings.Contains(text, "ytimg") {
+		t.Errorf("plain text digest includes a thumbnail URL:\n%s", text)
+	}
+}
+
+func TestResolveRecipients(t *testing.T) {
+	const sender = "me@example.com"
+	tests := []struct {
+		name       string
+		recipients []string
+		sendToSelf bool
+		wantTo     []string
+		wantBCC    []string
+	}{
+		{"no recipients", nil, false, []string{sender}, nil},
+		{"no recipients, send to self", nil, true, []string{sender}, nil},
+		{"recipients", []string{"a@example.com"}, false, []string{"a@example.com"}, nil},
+		{"recipients, send to self", []string{"a@example.com"}, true, []string{"a@example.com"}, []string{sender}},
+		{"sender among recipients", []string{"a@example.com", "Me@Example.com"}, true, []string{"a@example.com", "Me@Example.com"}, nil},
+	}
+
+	for _, tt := range tests {
+		to, bcc := resolveRecipients(tt.recipients, sender, tt.sendToSelf)
+		if !reflect.DeepEqual(to, tt.wantTo) || !reflect.DeepEqual(bcc, tt.wantBCC) {
+			t.Errorf("%s: resolveRecipients() = %v, %v, want %v, %v", tt.name, to, bcc, tt.wantTo, tt.wantBCC)
+		}
+	}
+}
+
+var (
+	imgTagPattern = regexp.MustCompile(`(?is)<img\b[^>]*>`)
+	altPattern    = regexp.MustCompile(`(?is)\balt="([^"]*)"`)
+)
+
+func TestDigestImagesHaveAltText(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Email.HeaderImageURL = "https://example.com/logo.png"
+	es, err := NewEmailService(cfg, "me@example.com", "secret", nopLogger{})
+	if err != nil {
+		t.Fatalf("NewEmailService() error = %v", err)
+	}
+
+	summary := types.Summary{
+		ID:           "sum_1",
+		VideoID:      "abc",
+		VideoTitle:   "A video",
+		ChannelName:  "A channel",
+		Summary:      "The summary.",
+		Status:       "New",
+		VideoURL:     "https://www.youtube.com/watch?v=abc",
+		PublishedAt:  time.Date(2025, time.March, 4, 10, 0, 0, 0, time.UTC),
+		ThumbnailURL: "https://i.ytimg.com/vi/abc/hqdefault.jpg",
+		Duration:     "PT10M",
+	}
+	_, body, err := es.generateEmailContent(EmailData{
+		Title:          cfg.Email.DigestTitle,
+		HeaderImageURL: cfg.Email.HeaderImageURL,
+		Date:           "March 5, 2025",
+		Summaries:      []types.Summary{summary},
+		TotalCount:     1,
+	})
+	if err != nil {
+		t.Fatalf("generateEmailContent() error = %v", err)
+	}
+
+	images := imgTagPattern.FindAllString(body, -1)
+	if len(images) != 2 {
+		t.Fatalf("found %d <img> tags, want the header image and one thumbnail", len(images))
+	}
+	for _, img := range images {
+		alt := altPattern.FindStringSubmatch(img)
+		if alt == nil || strings.TrimSpace(alt[1]) == "" {
+			t.Errorf("image without alt text: %s", img)
+		}
+	}
+}
+
+// fakeSMTPServer accepts mail for every recipient except those containing "reject"
+// and records the accepted recipients of each delivered message
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	accepted []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeSMTPServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+// deliveredTo returns the sorted recipients of all delivered messages
+func (s *fakeSMTPServer) deliveredTo() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	accepted := append([]string(nil), s.accepted...)
+	sort.Strings(accepted)
+	return accepted
+}
+
+// port returns the port the server listens on
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	var recipients []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(cmd, "MAIL FROM"):
+			recipients = nil
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO"):
+			if strings.Contains(cmd, "REJECT") {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			recipients = append(recipients, strings.ToLower(strings.Trim(strings.TrimSpace(line[len("RCPT TO:"):]), "<>")))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			for {
+				data, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if data == ".\r\n" {
+					break
+				}
+			}
+			s.mu.Lock()
+			s.accepted = append(s.accepted, recipients...)
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// newTestEmailService returns an email service that delivers to server without SMTP AUTH
+func newTestEmailService(t *testing.T, server *fakeSMTPServer, configure func(*types.Config)) *EmailService {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Email.SMTPHost = "127.0.0.1"
+	cfg.Email.SMTPPort = server.port()
+	cfg.Email.Auth = "none"
+	cfg.Email.PlainText = false
+	cfg.Email.Recipients = []string{"main@example.com"}
+	configure(cfg)
+	es, err := NewEmailService(cfg, "", "", nopLogger{})
+	if err != nil {
+		t.Fatalf("NewEmailService() error = %v", err)
+	}
+	return es
+}
+
+// summaryIDs returns the sorted IDs of summaries
+func summaryIDs(summaries []types.Summary) []string {
+	var ids []string
+	for _, summary := range summaries {
+		ids = append(ids, summary.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// testSummary returns a minimal summary in a channel and group
+func testSummary(n int, channelID, group string) types.Summary {
+	id := strconv.Itoa(n)
+	return types.Summary{
+		ID:          "sum_" + id,
+		VideoID:     "video" + id,
+		VideoTitle:  "Video " + id,
+		ChannelID:   channelID,
+		ChannelName: "Channel " + channelID,
+		Group:       group,
+		Summary:     "Summary " + id,
+		Status:      "New",
+		VideoURL:    "https://www.youtube.com/watch?v=video" + id,
+	}
+}
+
+func TestSendDigestKeepsSentGroupsWhenOneFails(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	es := newTestEmailService(t, server, func(cfg *types.Config) {
+		cfg.Email.GroupBy = "group"
+		cfg.Email.GroupRecipients = map[string][]string{
+			"tech": {"tech@example.com"},
+			"news": {"reject@example.com"},
+		}
+	})
+
+	summaries := []types.Summary{
+		testSummary(1, "UCa", "tech"),
+		testSummary(2, "UCb", "news"),
+		testSummary(3, "UCc", ""),
+	}
+	err := es.SendDigest(context.Background(), summaries)
+
+	var digestErr *DigestError
+	if !errors.As(err, &digestErr) {
+		t.Fatalf("SendDigest() error = %v, want a *DigestError", err)
+	}
+	if !strings.Contains(err.Error(), "news") {
+		t.Errorf("error %q doesn't name the failed group", err)
+	}
+	if got, want := summaryIDs(SentSummaries(err)), []string{"sum_1", "sum_3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SentSummaries() = %v, want %v", got, want)
+	}
+
+	if got, want := server.deliveredTo(), []string{"main@example.com", "tech@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered to %v, want %v", got, want)
+	}
+}
+
+func TestSendDigestTracksEachRoute(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	es := newTestEmailService(t, server, func(cfg *types.Config) {
+		cfg.Email.Recipients = []string{"reject-main@example.com"}
+		cfg.Email.Routes = map[string][]string{
+			"UCa":  {"channel@example.com"},
+			"news": {"reject-news@example.com"},
+			"tech": {"tech@example.com"},
+		}
+	})
+
+	summaries := []types.Summary{
+		testSummary(1, "UCa", "news"), // the channel route wins over its group's
+		testSummary(2, "UCb", "news"),
+		testSummary(3, "UCc", "tech"),
+		testSummary(4, "UCd", ""),
+	}
+	err := es.SendDigest(context.Background(), summaries)
+	if err == nil {
+		t.Fatal("SendDigest() succeeded, want an error for the failed routes")
+	}
+	if got, want := summaryIDs(SentSummaries(err)), []string{"sum_1", "sum_3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SentSummaries() = %v, want %v", got, want)
+	}
+	if got, want := server.deliveredTo(), []string{"channel@example.com", "tech@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered to %v, want %v", got, want)
+	}
+
+	// Every digest going out leaves nothing to report
+	server = newFakeSMTPServer(t)
+	es = newTestEmailService(t, server, func(cfg *types.Config) {
+		cfg.Email.Routes = map[string][]string{"UCa": {"channel@example.com"}}
+	})
+	if err := es.SendDigest(context.Background(), summaries); err != nil {
+		t.Errorf("SendDigest() error = %v", err)
+	}
+	if got, want := server.deliveredTo(), []string{"channel@example.com", "main@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered to %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"youtube-summarizer/internal/clients"
+	"youtube-summarizer/pkg/types"
+)
+
+// maxThumbnailBytes caps a single embedded thumbnail download
+const maxThumbnailBytes = 2 << 20
+
+// embeddedImage is a file attached to the email; images embedded inline are referenced by cid
+type embeddedImage struct {
+	name string
+	data []byte
+}
+
+// embedThumbnails downloads the summaries' thumbnails with a bounded worker pool
+// and returns copies of the summaries pointing at inline cid: images. Results are
+// indexed by position so the digest order is unchanged; failed downloads keep
+// their original URL.
+func (es *EmailService) embedThumbnails(ctx context.Context, summaries []types.Summary) ([]types.Summary, []embeddedImage) {
+	workers := es.config.Email.RenderWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	httpClient := clients.NewHTTPClient(es.config.Timeouts.YouTube, es.config.HTTP.Proxy, clients.RetryPolicyFromConfig(es.config.HTTP))
+	images := make([]*embeddedImage, len(summaries))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				data, err := fetchThumbnail(ctx, httpClient, summaries[i].ThumbnailURL)
+				if err != nil {
+					es.logger.Warn("Failed to embed thumbnail, linking it instead", "videoID", summaries[i].VideoID, "error", err)
+					continue
+				}
+				images[i] = &embeddedImage{name: fmt.Sprintf("thumb-%d.jpg", i), data: data}
+			}
+		}()
+	}
+
+	for i, summary := range summaries {
+		if summary.ThumbnailURL != "" {
+			jobs <- i
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	embedded := make([]types.Summary, len(summaries))
+	copy(embedded, summaries)
+
+	var attachments []embeddedImage
+	for i, image := range images {
+		if image == nil {
+			continue
+		}
+		embedded[i].ThumbnailURL = "cid:" + image.name
+		attachments = append(attachments, *image)
+	}
+
+	es.logger.Debug("Embedded thumbnails", "embedded", len(attachments), "total", len(summaries), "workers", workers)
+	return embedded, attachments
+}
+
+// fetchThumbnail downloads a single thumbnail image
+func fetchThumbnail(ctx context.Context, httpClient *clients.HTTPClient, url string) ([]byte, error) {
+	resp, err := httpClient.Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("thumbnail download returned status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxThumbnailBytes))
+}
+
+// thumbnailSrc marks cid: references as safe so html/template doesn't rewrite them
+func thumbnailSrc(url string) interface{} {
+	if strings.HasPrefix(url, "cid:") {
+		return template.URL(url)
+	}
+	return url
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"unicode"
+
+	"youtube-summarizer/pkg/types"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+)
+
+// markdownPolicy strips anything from rendered summaries that isn't safe user content
+var markdownPolicy = bluemonday.UGCPolicy()
+
+// emailTemplateFuncs returns templateFuncs with summaryBody rendering markdown
+// when email.render_markdown is enabled, and clip/clipped applying email.summary_max_chars
+func emailTemplateFuncs(config *types.Config) template.FuncMap {
+	funcs := make(template.FuncMap, len(templateFuncs)+3)
+	for name, fn := range templateFuncs {
+		funcs[name] = fn
+	}
+	if config.Email.RenderMarkdown {
+		funcs["summaryBody"] = renderMarkdown
+	}
+
+	maxChars := config.Email.SummaryMaxChars
+	funcs["clip"] = func(summary string) string {
+		clipped, _ := truncateSummary(summary, maxChars)
+		return clipped
+	}
+	funcs["clipped"] = func(summary string) bool {
+		_, truncated := truncateSummary(summary, maxChars)
+		return truncated
+	}
+	return funcs
+}
+
+// truncateSummary shortens summary to at most maxChars characters, cutting at the
+// last word boundary; it reports whether anything was cut. maxChars <= 0 disables it.
+// Truncation happens on the raw text, before escaping, so it can't split an entity.
+func truncateSummary(summary string, maxChars int) (string, bool) {
+	runes := []rune(summary)
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return summary, false
+	}
+
+	cut := maxChars
+	for cut > 0 && !unicode.IsSpace(runes[cut]) {
+		cut--
+	}
+	// A single word longer than the limit is cut mid-word rather than dropped
+	if cut == 0 {
+		cut = maxChars
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}), true
+}
+
+// plainSummary escapes a summary for display as-is
+func plainSummary(summary string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(summary))
+}
+
+// renderMarkdown converts a summary's markdown to sanitized HTML, falling back
+// to the escaped text if it can't be converted
+func renderMarkdown(summary string) template.HTML {
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(summary), &buf); err != nil {
+		return plainSummary(summary)
+	}
+	return template.HTML(markdownPolicy.SanitizeBytes(buf.Bytes()))
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// pdfTimeout bounds a single HTML-to-PDF conversion
+const pdfTimeout = 60 * time.Second
+
+// renderPDF converts the digest HTML to a PDF by piping it through email.pdf_command,
+// which must read HTML on stdin and write the PDF to stdout (wkhtmltopdf does with "- -").
+// Inline cid: thumbnails can't be resolved by the converter and are left out.
+func (es *EmailService) renderPDF(ctx context.Context, html string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, pdfTimeout)
+	defer cancel()
+
+	command := es.config.Email.PDFCommand
+	cmd := exec.CommandContext(ctx, command, "--quiet", "--encoding", "utf-8", "-", "-")
+	cmd.Stdin = strings.NewReader(html)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("failed to run %s: %w: %s", command, err, message)
+		}
+		return nil, fmt.Errorf("failed to run %s: %w", command, err)
+	}
+	if !bytes.HasPrefix(stdout.Bytes(), []byte("%PDF")) {
+		return nil, fmt.Errorf("%s did not produce a PDF", command)
+	}
+	return stdout.Bytes(), nil
+}
@@ -4,11 +4,18 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"youtube-summarizer/internal/config"
 	"youtube-summarizer/pkg/types"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // VideoProcessor implements the types.VideoProcessor interface
@@ -17,8 +24,47 @@ type VideoProcessor struct {
 	youtubeClient    types.YouTubeClient
 	transcriptClient types.TranscriptClient
 	aiClient         types.AIClient
+	thumbnails       types.ThumbnailCache
+	summaryCache     types.SummaryCache
+	transcripts      types.TranscriptStore
 	config           *types.Config
 	logger           types.Logger
+	// boilerplate strips ai.strip_phrases and ai.channel_markers (nil when neither is set)
+	boilerplate *boilerplateFilter
+
+	// Semaphores bounding concurrent transcript fetches and AI calls across all channels
+	transcriptSem chan struct{}
+	aiSem         chan struct{}
+
+	// running is set while ProcessNewVideos runs, so UpdateConfig can't swap the
+	// semaphores out from under it
+	runMu   sync.Mutex
+	running bool
+
+	// transcriptFlight shares concurrent transcript fetches of the same video
+	// (processing.share_transcript_fetches)
+	transcriptFlight singleflight.Group
+
+	// Per-run AI failure tracking for processing.abort_after_failures
+	failureMu             sync.Mutex
+	consecutiveAIFailures int
+	abortErr              error
+	cancelRun             context.CancelFunc
+
+	// run records the current run's outcome for -run-log
+	run *runRecorder
+
+	// publishedAfter, when set (-since-last-run), limits each channel to videos published after it
+	publishedAfter time.Time
+
+	// checkpointPath, when set, records finished channels during a run; with
+	// resumeRun, channels finished by an interrupted run are skipped (-resume)
+	checkpointPath string
+	resumeRun      bool
+
+	// channelActivity is loaded once per run when youtube.dormant_after is set
+	// (nil otherwise) and only read while channels are processed
+	channelActivity map[string]types.ChannelActivity
 }
 
 // NewVideoProcessor creates a new video processor
@@ -37,11 +83,23 @@ func NewVideoProcessor(
 		aiClient:         aiClient,
 		config:           config,
 		logger:           logger,
+		transcriptSem:    make(chan struct{}, config.Processing.MaxConcurrentTranscripts),
+		aiSem:            make(chan struct{}, config.Processing.MaxConcurrentVideos),
+		boilerplate:      newBoilerplateFilter(config.AI),
 	}
 }
 
 // ProcessNewVideos processes new videos from all configured channels
 func (vp *VideoProcessor) ProcessNewVideos(ctx context.Context) error {
+	vp.runMu.Lock()
+	vp.running = true
+	vp.runMu.Unlock()
+	defer func() {
+		vp.runMu.Lock()
+		vp.running = false
+		vp.runMu.Unlock()
+	}()
+
 	vp.logger.Info("Starting video processing cycle")
 
 	// Get all channels to monitor
@@ -49,6 +107,7 @@ func (vp *VideoProcessor) ProcessNewVideos(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get channels: %w", err)
 	}
+	channels = mergeConfigChannels(channels, vp.config.YouTube.Channels)
 
 	if len(channels) == 0 {
 		vp.logger.Info("No channels configured for monitoring")
@@ -56,6 +115,44 @@ func (vp *VideoProcessor) ProcessNewVideos(ctx context.Context) error {
 	}
 
 	vp.logger.Info("Processing channels", "count", len(channels))
+	vp.run = newRunRecorder()
+
+	// Channel history decides which channels get the first-run limit
+	firstProcessed, err := vp.storage.GetChannelsFirstProcessed(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get channel history: %w", err)
+	}
+	appFirstRun := len(firstProcessed) == 0
+
+	// Upload history lets dormant channels skip the search call on most runs
+	vp.channelActivity = nil
+	if vp.config.YouTube.DormantAfter > 0 {
+		vp.channelActivity, err = vp.storage.GetChannelActivity(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get channel activity: %w", err)
+		}
+	}
+
+	// Track finished channels so an interrupted run can be resumed
+	var checkpoint *runCheckpoint
+	if vp.checkpointPath != "" {
+		checkpoint, err = openCheckpoint(vp.checkpointPath, vp.resumeRun)
+		if err != nil {
+			return err
+		}
+		if n := checkpoint.finished(); n > 0 {
+			vp.logger.Info("Resuming interrupted run", "startedAt", checkpoint.state.StartedAt.Format("2006-01-02 15:04:05"), "finishedChannels", n)
+		}
+	}
+
+	// Cancel the rest of the run if too many AI calls fail in a row
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	vp.failureMu.Lock()
+	vp.consecutiveAIFailures = 0
+	vp.abortErr = nil
+	vp.cancelRun = cancel
+	vp.failureMu.Unlock()
 
 	// Process each channel concurrently with a semaphore to limit concurrency
 	semaphore := make(chan struct{}, vp.config.Processing.MaxConcurrentVideos)
@@ -71,9 +168,28 @@ func (vp *VideoProcessor) ProcessNewVideos(ctx context.Context) error {
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			if err := vp.processChannel(ctx, ch); err != nil {
+			if checkpoint != nil && checkpoint.done(ch.ID) {
+				vp.logger.Debug("Skipping channel finished before the run was interrupted", "channelID", ch.ID, "channelName", ch.Name)
+				return
+			}
+
+			if vp.isDormant(ch.ID, time.Now()) {
+				vp.logger.Debug("Skipping dormant channel until its next recheck", "channelID", ch.ID, "channelName", ch.Name)
+				return
+			}
+
+			_, seen := firstProcessed[ch.ID]
+			if err := vp.processChannel(ctx, ch, vp.firstRunLimit(seen, appFirstRun), vp.channelPublishedAfter(ch.ID, seen)); err != nil {
 				vp.logger.Error("Failed to process channel", err, "channelID", ch.ID, "channelName", ch.Name)
+				vp.run.channelFailed(ch.ID)
 				errorsChan <- fmt.Errorf("channel %s (%s): %w", ch.Name, ch.ID, err)
+				return
+			}
+
+			if checkpoint != nil && ctx.Err() == nil {
+				if err := checkpoint.markDone(ch.ID); err != nil {
+					vp.logger.Warn("Failed to save checkpoint", "channelID", ch.ID, "error", err)
+				}
 			}
 		}(channel)
 	}
@@ -82,44 +198,104 @@ func (vp *VideoProcessor) ProcessNewVideos(ctx context.Context) error {
 	wg.Wait()
 	close(errorsChan)
 
+	vp.failureMu.Lock()
+	abortErr := vp.abortErr
+	vp.failureMu.Unlock()
+	if abortErr != nil {
+		return abortErr
+	}
+
 	// Collect errors
-	var errors []error
+	var errs []error
 	for err := range errorsChan {
-		errors = append(errors, err)
+		errs = append(errs, err)
 	}
 
-	if len(errors) > 0 {
-		vp.logger.Warn("Some channels failed to process", "errorCount", len(errors))
+	if len(errs) > 0 {
+		vp.logger.Warn("Some channels failed to process", "errorCount", len(errs))
 		// Don't fail the entire process if some channels fail
-		for _, err := range errors {
+		for _, err := range errs {
 			vp.logger.Error("Channel processing error", err)
 		}
 	}
 
+	// Keep the checkpoint for -resume unless every channel finished
+	if checkpoint != nil && len(errs) == 0 && ctx.Err() == nil {
+		if err := checkpoint.clear(); err != nil {
+			vp.logger.Warn("Failed to remove checkpoint", "error", err)
+		}
+	}
+
 	vp.logger.Info("Completed video processing cycle")
 	return nil
 }
 
+// mergeConfigChannels appends channels listed in youtube.channels that aren't
+// already in storage. Entries may hold several comma-separated IDs.
+func mergeConfigChannels(channels []types.Channel, configured []string) []types.Channel {
+	seen := make(map[string]bool, len(channels))
+	for _, ch := range channels {
+		seen[ch.ID] = true
+	}
+
+	for _, entry := range configured {
+		for _, id := range strings.Split(entry, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			channels = append(channels, types.Channel{ID: id, Name: id})
+		}
+	}
+	return channels
+}
+
 // processChannel processes videos from a single channel
-func (vp *VideoProcessor) processChannel(ctx context.Context, channel types.Channel) error {
+func (vp *VideoProcessor) processChannel(ctx context.Context, channel types.Channel, limit int, publishedAfter time.Time) error {
 	vp.logger.Debug("Processing channel", "channelID", channel.ID, "channelName", channel.Name)
 
+	// Channels may be listed by @handle or username instead of their UC... ID
+	channelID := channel.ID
+	if !strings.HasPrefix(channelID, "UC") {
+		resolved, err := vp.youtubeClient.ResolveChannelID(ctx, channelID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve channel %s: %w", channelID, err)
+		}
+		vp.logger.Debug("Resolved channel handle", "channel", channelID, "channelID", resolved)
+		channelID = resolved
+	}
+
 	// Get recent videos from the channel
-	videos, err := vp.youtubeClient.GetChannelVideos(ctx, channel.ID, vp.config.YouTube.MaxVideosPerChannel)
+	videos, err := vp.youtubeClient.GetChannelVideos(ctx, channelID, vp.config.YouTube.MaxVideosPerChannel, publishedAfter)
 	if err != nil {
+		if isFatalAPIError(err) {
+			vp.abortRun(err)
+		}
 		return fmt.Errorf("failed to get channel videos: %w", err)
 	}
 
 	vp.logger.Debug("Retrieved videos from channel", "channelID", channel.ID, "count", len(videos))
+	vp.run.channel(channel.ID, len(videos))
+	vp.recordChannelActivity(ctx, channel.ID, videos)
 
-	// Process each video with rate limiting
-	processedCount := 0
-	for i, video := range videos {
-		// Add delay between videos to respect API limits (except for first video)
-		if i > 0 {
-			vp.logger.Debug("Rate limiting: waiting 2 seconds before next video")
-			time.Sleep(2 * time.Second)
+	// Sort explicitly rather than trusting the API's order, so the first-run limit
+	// keeps the intended end of the list
+	oldestFirst := vp.config.Processing.Order == "oldest"
+	sort.SliceStable(videos, func(i, j int) bool {
+		if oldestFirst {
+			return videos[i].PublishedAt.Before(videos[j].PublishedAt)
 		}
+		return videos[i].PublishedAt.After(videos[j].PublishedAt)
+	})
+
+	// Fetch transcripts concurrently, then summarize as each transcript arrives.
+	// Transcript fetches and AI calls are bounded by separate semaphores.
+	var processedCount int64
+	var wg sync.WaitGroup
+	queued := 0
+	for _, video := range videos {
+		video.Group = channel.Group
 
 		// Check if video is already processed
 		processed, err := vp.storage.IsVideoProcessed(ctx, video.ID)
@@ -133,13 +309,61 @@ func (vp *VideoProcessor) processChannel(ctx context.Context, channel types.Chan
 			continue
 		}
 
-		// Process the video
-		if err := vp.processVideo(ctx, video); err != nil {
-			vp.logger.Error("Failed to process video", err, "videoID", video.ID, "title", video.Title)
+		// Videos outside the length band are recorded so they aren't reconsidered
+		if !vp.inDurationBand(ctx, &video) {
+			vp.run.skipped(video.ID)
+			if err := vp.storage.MarkVideoProcessed(ctx, video); err != nil {
+				vp.logger.Error("Failed to mark video as processed", err, "videoID", video.ID)
+			}
 			continue
 		}
 
-		processedCount++
+		// Past the first-run limit, older videos are recorded without summarizing so
+		// they don't all arrive on the next run instead; oldest-first backfills leave
+		// the newer videos for later runs
+		if limit > 0 && queued >= limit {
+			if oldestFirst {
+				vp.logger.Debug("First-run limit reached, leaving video for a later run", "videoID", video.ID, "limit", limit)
+				continue
+			}
+			vp.logger.Debug("First-run limit reached, marking video processed", "videoID", video.ID, "limit", limit)
+			vp.run.skipped(video.ID)
+			if err := vp.storage.MarkVideoProcessed(ctx, video); err != nil {
+				vp.logger.Error("Failed to mark video as processed", err, "videoID", video.ID)
+			}
+			continue
+		}
+		queued++
+
+		wg.Add(1)
+		go func(v types.Video) {
+			defer wg.Done()
+
+			// The run may have been aborted while this video was queued
+			if ctx.Err() != nil {
+				return
+			}
+
+			summary, err := vp.processVideo(ctx, v)
+
+			if err != nil {
+				vp.logger.Error("Failed to process video", err, "videoID", v.ID, "title", v.Title)
+				vp.run.failed(v.ID)
+				vp.recordFailure(ctx, channel, v, err)
+				return
+			}
+			if summary.Status == "Skipped" {
+				vp.run.skipped(v.ID)
+			} else {
+				vp.run.summarized(v.ID)
+			}
+			atomic.AddInt64(&processedCount, 1)
+		}(video)
+	}
+	wg.Wait()
+
+	if err := vp.storage.MarkChannelFirstProcessed(ctx, channel.ID, time.Now()); err != nil {
+		vp.logger.Warn("Failed to record channel history", "channelID", channel.ID, "error", err)
 	}
 
 	vp.logger.Info("Completed channel processing",
@@ -151,82 +375,586 @@ func (vp *VideoProcessor) processChannel(ctx context.Context, channel types.Chan
 	return nil
 }
 
-// getTranscriptAndThumbnail gets transcript and best thumbnail URL from the API
-func (vp *VideoProcessor) getTranscriptAndThumbnail(ctx context.Context, videoID string) (string, string, error) {
-	// Use the new method that returns both transcript and thumbnail
-	data, err := vp.transcriptClient.GetTranscriptWithThumbnail(ctx, videoID)
+// recordFailure adds a video that failed all its retries to the failed videos list.
+// Videos cut short by an aborted or timed-out run aren't recorded; they are simply
+// tried again next run.
+func (vp *VideoProcessor) recordFailure(ctx context.Context, channel types.Channel, video types.Video, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	channelName := video.ChannelName
+	if channelName == "" {
+		channelName = channel.Name
+	}
+	failure := types.VideoFailure{
+		VideoID:   video.ID,
+		ChannelID: channel.ID,
+		Channel:   channelName,
+		Title:     video.Title,
+		Error:     err.Error(),
+		FailedAt:  time.Now(),
+	}
+	if err := vp.storage.RecordVideoFailure(ctx, failure); err != nil {
+		vp.logger.Warn("Failed to record failed video", "videoID", video.ID, "error", err)
+	}
+}
+
+// channelPublishedAfter returns the -since-last-run cutoff for a channel. Channels
+// never processed before get none, so the first-run limit applies to them as usual,
+// and a dormant channel's cutoff goes back to when it was last fetched.
+func (vp *VideoProcessor) channelPublishedAfter(channelID string, seen bool) time.Time {
+	if vp.publishedAfter.IsZero() || !seen {
+		return time.Time{}
+	}
+	if activity, ok := vp.channelActivity[channelID]; ok && activity.LastCheckedAt.Before(vp.publishedAfter) {
+		return activity.LastCheckedAt
+	}
+	return vp.publishedAfter
+}
+
+// isDormant reports whether a channel hasn't uploaded for youtube.dormant_after and
+// was already checked within youtube.dormant_recheck_interval
+func (vp *VideoProcessor) isDormant(channelID string, now time.Time) bool {
+	activity, ok := vp.channelActivity[channelID]
+	if !ok || activity.LastCheckedAt.IsZero() {
+		return false
+	}
+	if !activity.LastUploadAt.IsZero() && now.Sub(activity.LastUploadAt) < vp.config.YouTube.DormantAfter {
+		return false
+	}
+	return now.Sub(activity.LastCheckedAt) < vp.config.YouTube.DormantRecheckInterval
+}
+
+// recordChannelActivity stores the channel's newest upload and the time of this check
+func (vp *VideoProcessor) recordChannelActivity(ctx context.Context, channelID string, videos []types.Video) {
+	if vp.channelActivity == nil {
+		return
+	}
+
+	activity := vp.channelActivity[channelID]
+	for _, video := range videos {
+		if video.PublishedAt.After(activity.LastUploadAt) {
+			activity.LastUploadAt = video.PublishedAt
+		}
+	}
+	activity.LastCheckedAt = time.Now()
+
+	if err := vp.storage.SetChannelActivity(ctx, channelID, activity); err != nil {
+		vp.logger.Warn("Failed to record channel activity", "channelID", channelID, "error", err)
+	}
+}
+
+// inDurationBand reports whether a video's length is within processing.min_duration
+// and processing.max_duration, fetching its details when the duration isn't known yet.
+// Videos whose length can't be determined are kept rather than silently dropped.
+func (vp *VideoProcessor) inDurationBand(ctx context.Context, video *types.Video) bool {
+	minDuration, maxDuration := vp.config.Processing.MinDuration, vp.config.Processing.MaxDuration
+	if minDuration <= 0 && maxDuration <= 0 {
+		return true
+	}
+
+	if video.Duration == "" {
+		details, err := vp.youtubeClient.GetVideoDetails(ctx, video.ID)
+		if err != nil {
+			vp.logger.Warn("Failed to get video duration, processing anyway", "videoID", video.ID, "error", err)
+			return true
+		}
+		video.Duration = details.Duration
+		video.ViewCount = details.ViewCount
+	}
+
+	length, err := types.ParseISO8601Duration(video.Duration)
+	if err != nil || length == 0 {
+		// Live and upcoming streams report P0D until they finish
+		vp.logger.Debug("Video duration unknown, processing anyway", "videoID", video.ID, "duration", video.Duration)
+		return true
+	}
+
+	if durationInBand(length, minDuration, maxDuration) {
+		return true
+	}
+
+	vp.logger.Info("Video outside duration band, skipping",
+		"videoID", video.ID,
+		"title", video.Title,
+		"duration", types.FormatDuration(length))
+	return false
+}
+
+// durationInBand reports whether length is within [minDuration, maxDuration]; a zero bound is open
+func durationInBand(length, minDuration, maxDuration time.Duration) bool {
+	if minDuration > 0 && length < minDuration {
+		return false
+	}
+	if maxDuration > 0 && length > maxDuration {
+		return false
+	}
+	return true
+}
+
+// firstRunLimit returns the cap on new videos for a channel, or 0 for no cap. With
+// app.first_run_per_channel the cap applies to channels never processed before (a
+// pruned channel keeps its history); otherwise only to the app's very first run.
+func (vp *VideoProcessor) firstRunLimit(channelSeen, appFirstRun bool) int {
+	if vp.config.App.FirstRunPerChannel {
+		if channelSeen {
+			return 0
+		}
+	} else if !appFirstRun {
+		return 0
+	}
+	return vp.config.App.MaxVideosOnFirstRun
+}
+
+// videoContent holds the text to summarize for a video along with its thumbnail
+type videoContent struct {
+	transcript   string
+	thumbnailURL string
+	// fromTranscript is false when the video description was used as a fallback
+	fromTranscript bool
+	// transcriptMissing is set when the video has no transcript at all
+	transcriptMissing bool
+	// source records what the text came from (types.SourceTranscript etc.)
+	source string
+	// lang is the transcript's language code (empty if unknown)
+	lang string
+}
+
+// processVideo processes a single video (transcript + summary), summarizing again up
+// to processing.video_retries times after a transient failure. The content is fetched
+// once and only refetched when the transcript fetch failed and fell back to the description.
+func (vp *VideoProcessor) processVideo(ctx context.Context, video types.Video) (types.Summary, error) {
+	var content videoContent
+	for attempt := 0; ; attempt++ {
+		if attempt == 0 || content.source == types.SourceDescription {
+			vp.transcriptSem <- struct{}{}
+			content = vp.fetchVideoContent(ctx, video)
+			<-vp.transcriptSem
+		}
+
+		vp.aiSem <- struct{}{}
+		summary, err := vp.summarizeVideo(ctx, video, content)
+		<-vp.aiSem
+
+		if err == nil || !types.IsTransient(err) || attempt >= vp.config.Processing.VideoRetries || ctx.Err() != nil {
+			return summary, err
+		}
+
+		delay := vp.config.Processing.VideoRetryDelay
+		vp.logger.Warn("Video failed with a transient error, retrying", "videoID", video.ID, "attempt", attempt+1, "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return types.Summary{}, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// ErrVideoAlreadyProcessed is returned by ProcessVideo for a processed video unless forced
+var ErrVideoAlreadyProcessed = errors.New("video already processed")
+
+// ProcessVideo fetches, summarizes and stores a single video by ID, returning the saved
+// summary. A video that was already processed is rejected with ErrVideoAlreadyProcessed
+// unless force is set, in which case it is summarized again.
+func (vp *VideoProcessor) ProcessVideo(ctx context.Context, videoID string, force bool) (types.Summary, error) {
+	if !force {
+		processed, err := vp.storage.IsVideoProcessed(ctx, videoID)
+		if err != nil {
+			return types.Summary{}, fmt.Errorf("failed to check if video is processed: %w", err)
+		}
+		if processed {
+			return types.Summary{}, fmt.Errorf("%w: %s", ErrVideoAlreadyProcessed, videoID)
+		}
+	}
+
+	video, err := vp.youtubeClient.GetVideoDetails(ctx, videoID)
 	if err != nil {
-		return "", "", err
+		return types.Summary{}, fmt.Errorf("failed to get video details: %w", err)
 	}
+	video.Group = vp.channelGroup(ctx, video.ChannelID)
 
-	return data.Transcript, data.ThumbnailURL, nil
+	return vp.processVideo(ctx, *video)
 }
 
-// processVideo processes a single video (transcript + summary)
-func (vp *VideoProcessor) processVideo(ctx context.Context, video types.Video) error {
-	vp.logger.Debug("Processing video", "videoID", video.ID, "title", video.Title)
+// channelGroup returns the group of a monitored channel, or "" when the channel
+// isn't in the Channels sheet or can't be looked up
+func (vp *VideoProcessor) channelGroup(ctx context.Context, channelID string) string {
+	channels, err := vp.storage.GetChannels(ctx)
+	if err != nil {
+		vp.logger.Warn("Failed to look up channel group", "channelID", channelID, "error", err)
+		return ""
+	}
+	for _, channel := range channels {
+		if channel.ID == channelID {
+			return channel.Group
+		}
+	}
+	return ""
+}
+
+// fetchVideoContent gets the transcript and thumbnail, falling back to the video description
+func (vp *VideoProcessor) fetchVideoContent(ctx context.Context, video types.Video) videoContent {
+	vp.logger.Debug("Fetching video content", "videoID", video.ID, "title", video.Title)
 
-	// Create a timeout context for this video
+	// Create a timeout context for the transcript fetch
 	videoCtx, cancel := context.WithTimeout(ctx, vp.config.Processing.TranscriptTimeout)
 	defer cancel()
 
 	// Get the transcript, with fallback to video description
-	transcript, thumbnailURL, err := vp.getTranscriptAndThumbnail(videoCtx, video.ID)
+	data, err := vp.fetchTranscript(videoCtx, video.ID)
+	if errors.Is(err, types.ErrTranscriptUnavailable) {
+		vp.logger.Info("Video has no transcript", "videoID", video.ID)
+		thumbnailURL := fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", video.ID)
+		return videoContent{thumbnailURL: thumbnailURL, transcriptMissing: true}
+	}
 	if err != nil {
 		vp.logger.Warn("Transcript failed, using video description as fallback", "videoID", video.ID, "error", err)
 		// Use video title and description as fallback
-		transcript = fmt.Sprintf("Video Title: %s\n\nVideo Description: %s", video.Title, video.Description)
+		transcript := fmt.Sprintf("Video Title: %s\n\nVideo Description: %s", video.Title, video.Description)
 		if len(transcript) < 50 { // Very short description
 			transcript = fmt.Sprintf("Video Title: %s\n\nThis video discusses topics related to the title. Please watch the video for detailed content.", video.Title)
 		}
 		// Use default YouTube thumbnail as fallback
-		thumbnailURL = fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", video.ID)
+		thumbnailURL := fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", video.ID)
+		return videoContent{transcript: transcript, thumbnailURL: thumbnailURL, source: types.SourceDescription}
 	}
 
-	// Truncate transcript if it's too long
-	if len(transcript) > vp.config.AI.MaxTranscriptLength {
-		transcript = transcript[:vp.config.AI.MaxTranscriptLength] + "... [truncated]"
-		vp.logger.Debug("Truncated long transcript", "videoID", video.ID, "maxLength", vp.config.AI.MaxTranscriptLength)
+	source := data.Source
+	if source == "" {
+		source = types.SourceTranscript
 	}
+	vp.logger.Debug("Fetched transcript", "videoID", video.ID, "source", source, "lang", data.Language)
+	return videoContent{transcript: data.Transcript, thumbnailURL: data.ThumbnailURL, fromTranscript: true, source: source, lang: data.Language}
+}
 
-	// Generate summary using AI
-	summary, err := vp.aiClient.Summarize(ctx, transcript, video.Title)
+// fetchTranscript gets a video's transcript, joining a fetch of the same video
+// already in progress when processing.share_transcript_fetches is set
+func (vp *VideoProcessor) fetchTranscript(ctx context.Context, videoID string) (*types.TranscriptData, error) {
+	if !vp.config.Processing.ShareTranscriptFetches {
+		return vp.transcriptClient.GetTranscriptWithThumbnail(ctx, videoID)
+	}
+
+	result, err, shared := vp.transcriptFlight.Do(videoID, func() (interface{}, error) {
+		return vp.transcriptClient.GetTranscriptWithThumbnail(ctx, videoID)
+	})
+	if shared {
+		vp.logger.Debug("Shared transcript fetch", "videoID", videoID)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to generate summary: %w", err)
+		return nil, err
+	}
+	return result.(*types.TranscriptData), nil
+}
+
+// summarizeVideo summarizes the fetched content and persists the summary, returning the saved record
+func (vp *VideoProcessor) summarizeVideo(ctx context.Context, video types.Video, content videoContent) (types.Summary, error) {
+	vp.logger.Debug("Processing video", "videoID", video.ID, "title", video.Title)
+
+	transcript, thumbnailURL := content.transcript, content.thumbnailURL
+	if content.transcriptMissing {
+		vp.logger.Info("Skipping video without transcript", "videoID", video.ID, "title", video.Title)
+		return vp.skipVideo(ctx, video, thumbnailURL)
+	}
+	if minLength := vp.config.AI.MinTranscriptLength; content.fromTranscript && minLength > 0 && len(transcript) < minLength {
+		// Very short transcripts (intros, teasers) don't produce useful summaries
+		vp.logger.Info("Transcript too short, skipping summarization",
+			"videoID", video.ID,
+			"transcriptLength", len(transcript),
+			"minLength", minLength)
+		return vp.skipVideo(ctx, video, thumbnailURL)
+	}
+
+	// Keep the full transcript before truncation; a failed save shouldn't lose the summary
+	if vp.transcripts != nil && content.fromTranscript {
+		if err := vp.transcripts.Put(video.ID, content.transcript); err != nil {
+			vp.logger.Warn("Failed to save transcript", "videoID", video.ID, "error", err)
+		}
+	}
+
+	transcript, wordCount := vp.prepareTranscript(video, content)
+
+	// Generate summary using AI with the prompt for the video's category
+	category, prompt := vp.selectPrompt(video.Title)
+	vp.logger.Debug("Selected summary prompt", "videoID", video.ID, "category", category)
+
+	summary, provider, model, cached := "", "", "", false
+	if vp.summaryCache != nil {
+		summary, cached = vp.summaryCache.Get(video.ID, prompt)
+	}
+	if cached {
+		provider = "cached"
+		vp.logger.Info("Using cached summary", "videoID", video.ID)
+	} else {
+		var err error
+		summary, provider, err = vp.summarize(ctx, prompt, transcript, video.Title)
+		if isFatalAPIError(err) {
+			vp.abortRun(err)
+		} else {
+			vp.recordAIResult(err)
+		}
+		if err != nil {
+			return types.Summary{}, fmt.Errorf("failed to generate summary: %w", err)
+		}
+		model = vp.providerModel(provider)
+		vp.logger.Info("Summary generated", "videoID", video.ID, "provider", provider, "model", model)
+
+		if vp.summaryCache != nil {
+			if err := vp.summaryCache.Put(video.ID, prompt, summary); err != nil {
+				vp.logger.Warn("Failed to cache summary", "videoID", video.ID, "error", err)
+			}
+		}
+	}
+
+	var quotes []string
+	if vp.config.AI.ExtractQuotes {
+		quotes = vp.extractQuotes(ctx, transcript, video)
 	}
 
 	// Create summary record
+	summaryRecord := types.Summary{
+		ID:             vp.generateSummaryID(),
+		VideoID:        video.ID,
+		VideoTitle:     video.Title,
+		ChannelName:    video.ChannelName,
+		Summary:        summary,
+		CreatedAt:      time.Now(),
+		Status:         "New",
+		VideoURL:       video.URL,
+		PublishedAt:    video.PublishedAt,
+		ThumbnailURL:   thumbnailURL,
+		Duration:       types.HumanizeDuration(video.Duration),
+		ViewCount:      video.ViewCount,
+		WordCount:      wordCount,
+		ReadingMinutes: types.EstimateReadingMinutes(summary),
+		Provider:       provider,
+		Model:          model,
+		Source:         content.source,
+		TranscriptLang: content.lang,
+		Group:          video.Group,
+		Quotes:         quotes,
+		ChannelID:      video.ChannelID,
+		Audience:       vp.config.AI.Audience,
+	}
+
+	// Save the summary
+	if err := vp.saveSummary(ctx, &summaryRecord); err != nil {
+		return types.Summary{}, fmt.Errorf("failed to save summary: %w", err)
+	}
+
+	// Cache the thumbnail locally for the UI; a failure here shouldn't lose the summary
+	if vp.thumbnails != nil {
+		if _, err := vp.thumbnails.Fetch(ctx, video.ID, thumbnailURL); err != nil {
+			vp.logger.Warn("Failed to cache thumbnail", "videoID", video.ID, "error", err)
+		}
+	}
+
+	// Mark video as processed
+	if err := vp.storage.MarkVideoProcessed(ctx, video); err != nil {
+		return types.Summary{}, fmt.Errorf("failed to mark video as processed: %w", err)
+	}
+
+	vp.logger.Info("Successfully processed video",
+		"videoID", video.ID,
+		"title", video.Title,
+		"summaryLength", len(summary))
+
+	return summaryRecord, nil
+}
+
+// categoryKeywords maps content categories to title keywords used to detect them
+var categoryKeywords = []struct {
+	category string
+	keywords []string
+}{
+	{"tutorial", []string{"how to", "tutorial", "guide", "step by step", "walkthrough", "beginner", "learn", "course", "tips"}},
+	{"review", []string{"review", "hands-on", "hands on", "unboxing", "tested", " vs ", "versus", "comparison", "worth it"}},
+	{"news", []string{"news", "breaking", "update", "announced", "announces", "report", "this week", "today"}},
+}
+
+// detectCategory classifies a video from its title using keyword heuristics
+func detectCategory(title string) string {
+	lower := " " + strings.ToLower(title) + " "
+	for _, entry := range categoryKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(lower, keyword) {
+				return entry.category
+			}
+		}
+	}
+	return ""
+}
+
+// selectPrompt returns the detected category and the prompt configured for it,
+// with the ai.audience instruction added. Videos without a category prompt use
+// ai.summary_prompt.
+func (vp *VideoProcessor) selectPrompt(title string) (string, string) {
+	if len(vp.config.AI.Questions) > 0 {
+		return "questions", withAudience(buildQuestionsPrompt(vp.config.AI.Questions), vp.config.AI.Audience)
+	}
+
+	category := detectCategory(title)
+	if prompt := vp.config.AI.Prompts[category]; category != "" && prompt != "" {
+		return category, withAudience(prompt, vp.config.AI.Audience)
+	}
+	return "generic", withAudience(vp.config.AI.SummaryPrompt, vp.config.AI.Audience)
+}
+
+// audienceInstructions are the ai.audience instructions put before the prompt; the
+// general audience needs none
+var audienceInstructions = map[string]string{
+	"beginner": "Write for a beginner with no background in the topic: use plain words, explain any jargon or acronyms briefly, and favour the big picture over details.",
+	"expert":   "Write for an expert in the topic: assume the background knowledge, use precise technical terms without explaining them, and focus on the specifics, numbers and nuances.",
+}
+
+// withAudience puts the audience's instruction before a prompt template, filling in
+// the built-in prompt when the template is empty
+func withAudience(prompt, audience string) string {
+	instruction := audienceInstructions[audience]
+	if instruction == "" {
+		return prompt
+	}
+	if prompt == "" {
+		prompt = types.DefaultPromptTemplate
+	}
+	return instruction + "\n\n" + prompt
+}
+
+// buildQuestionsPrompt asks the model to answer each question from the transcript
+// as "Q:"/"A:" pairs, which the email renders as a Q&A list
+func buildQuestionsPrompt(questions []string) string {
+	var prompt strings.Builder
+	prompt.WriteString("Video Title: \"{title}\". Using only the following video transcript, answer each question below.\n")
+	prompt.WriteString("If the transcript doesn't cover a question, answer \"Not covered.\"\n")
+	prompt.WriteString("Format every answer exactly as:\nQ: <question>\nA: <answer>\n\nQuestions:\n")
+	for _, question := range questions {
+		fmt.Fprintf(&prompt, "- %s\n", question)
+	}
+	prompt.WriteString("\nTranscript:\n{transcript}")
+	return prompt.String()
+}
+
+// recordAIResult tracks consecutive AI failures and aborts the run once
+// processing.abort_after_failures is reached, so systemic problems like an
+// expired API key fail fast instead of grinding through every channel
+func (vp *VideoProcessor) recordAIResult(err error) {
+	limit := vp.config.Processing.AbortAfterFailures
+
+	vp.failureMu.Lock()
+	defer vp.failureMu.Unlock()
+
+	if err == nil {
+		vp.consecutiveAIFailures = 0
+		return
+	}
+
+	vp.consecutiveAIFailures++
+	if limit <= 0 || vp.consecutiveAIFailures < limit || vp.abortErr != nil {
+		return
+	}
+
+	vp.abortErr = fmt.Errorf("aborting run after %d consecutive AI failures (last error: %w)", vp.consecutiveAIFailures, err)
+	vp.logger.Error("Too many consecutive AI failures, aborting run", err, "failures", vp.consecutiveAIFailures)
+	if vp.cancelRun != nil {
+		vp.cancelRun()
+	}
+}
+
+// abortRun stops the run for errors that will fail every remaining request,
+// such as a rejected API key or exhausted quota
+func (vp *VideoProcessor) abortRun(err error) {
+	vp.failureMu.Lock()
+	defer vp.failureMu.Unlock()
+
+	if vp.abortErr != nil {
+		return
+	}
+
+	vp.abortErr = fmt.Errorf("aborting run: %w", err)
+	vp.logger.Error("Unrecoverable API error, aborting run", err)
+	if vp.cancelRun != nil {
+		vp.cancelRun()
+	}
+}
+
+// isFatalAPIError reports whether retrying other videos is pointless
+func isFatalAPIError(err error) bool {
+	return errors.Is(err, types.ErrAuth) || errors.Is(err, types.ErrQuotaExceeded)
+}
+
+// summarize calls the AI client, asking for the provider name when the client supports it.
+// Rate-limited requests are retried by the clients themselves (ai.rate_limit_retries
+// for Claude, http.retry_attempts otherwise), so they are not retried here.
+func (vp *VideoProcessor) summarize(ctx context.Context, prompt, transcript, title string) (string, string, error) {
+	if pc, ok := vp.aiClient.(types.ProviderAIClient); ok {
+		return pc.SummarizeWithProvider(ctx, prompt, transcript, title, vp.config.AI.Examples)
+	}
+	summary, err := vp.aiClient.SummarizeWithPrompt(ctx, prompt, transcript, title)
+	return summary, "", err
+}
+
+// providerModel returns the model behind an AI provider name, if the client reports it
+func (vp *VideoProcessor) providerModel(provider string) string {
+	if pc, ok := vp.aiClient.(types.ProviderAIClient); ok {
+		return pc.ProviderModel(provider)
+	}
+	return ""
+}
+
+// prepareTranscript strips configured boilerplate from the transcript, truncates it to
+// ai.max_transcript_length and returns it with the transcript's word count (0 when
+// summarizing the description instead)
+func (vp *VideoProcessor) prepareTranscript(video types.Video, content videoContent) (string, int) {
+	transcript := content.transcript
+
+	// Count words before stripping and truncation; a description fallback says nothing about the video's length
+	wordCount := 0
+	if content.fromTranscript {
+		wordCount = len(strings.Fields(transcript))
+	}
+
+	if vp.boilerplate != nil && content.fromTranscript {
+		stripped := vp.boilerplate.strip(transcript, video)
+		if len(stripped) < len(transcript) {
+			vp.logger.Debug("Stripped transcript boilerplate", "videoID", video.ID, "removedChars", len(transcript)-len(stripped))
+		}
+		transcript = stripped
+	}
+
+	// Truncate transcript if it's too long
+	if len(transcript) > vp.config.AI.MaxTranscriptLength {
+		transcript = transcript[:vp.config.AI.MaxTranscriptLength] + "... [truncated]"
+		vp.logger.Debug("Truncated long transcript", "videoID", video.ID, "maxLength", vp.config.AI.MaxTranscriptLength)
+	}
+	return transcript, wordCount
+}
+
+// skipVideo records a video as skipped without summarizing it so it isn't reconsidered
+func (vp *VideoProcessor) skipVideo(ctx context.Context, video types.Video, thumbnailURL string) (types.Summary, error) {
 	summaryRecord := types.Summary{
 		ID:           vp.generateSummaryID(),
 		VideoID:      video.ID,
 		VideoTitle:   video.Title,
 		ChannelName:  video.ChannelName,
-		Summary:      summary,
 		CreatedAt:    time.Now(),
-		Status:       "New",
+		Status:       "Skipped",
 		VideoURL:     video.URL,
 		PublishedAt:  video.PublishedAt,
 		ThumbnailURL: thumbnailURL,
-		Duration:     video.Duration,
+		Duration:     types.HumanizeDuration(video.Duration),
 		ViewCount:    video.ViewCount,
+		Group:        video.Group,
 	}
 
-	// Save the summary
-	if err := vp.storage.SaveSummary(ctx, summaryRecord); err != nil {
-		return fmt.Errorf("failed to save summary: %w", err)
+	if err := vp.saveSummary(ctx, &summaryRecord); err != nil {
+		return types.Summary{}, fmt.Errorf("failed to save skipped summary: %w", err)
 	}
 
-	// Mark video as processed
-	if err := vp.storage.MarkVideoProcessed(ctx, video.ID); err != nil {
-		return fmt.Errorf("failed to mark video as processed: %w", err)
+	if err := vp.storage.MarkVideoProcessed(ctx, video); err != nil {
+		return types.Summary{}, fmt.Errorf("failed to mark video as processed: %w", err)
 	}
 
-	vp.logger.Info("Successfully processed video",
-		"videoID", video.ID,
-		"title", video.Title,
-		"summaryLength", len(summary))
-
-	return nil
+	return summaryRecord, nil
 }
 
 // GetProcessedVideos retrieves all processed videos
@@ -237,13 +965,69 @@ func (vp *VideoProcessor) GetProcessedVideos(ctx context.Context) ([]types.Video
 	return []types.Video{}, nil
 }
 
-// UpdateConfig updates the processor configuration
-func (vp *VideoProcessor) UpdateConfig(config types.Config) error {
-	vp.config = &config
+// SetSummaryCache enables reuse of summaries for identical video and prompt pairs
+func (vp *VideoProcessor) SetSummaryCache(cache types.SummaryCache) {
+	vp.summaryCache = cache
+}
+
+// SetPublishedAfter limits channels to videos published after t (-since-last-run);
+// the zero time removes the limit
+func (vp *VideoProcessor) SetPublishedAfter(t time.Time) {
+	vp.publishedAfter = t
+}
+
+// SetCheckpoint records finished channels in path during each run; with resume, the
+// next run skips the channels an interrupted run already finished (-resume)
+func (vp *VideoProcessor) SetCheckpoint(path string, resume bool) {
+	vp.checkpointPath = path
+	vp.resumeRun = resume
+}
+
+// SetTranscriptStore enables saving each fetched transcript (storage.save_transcripts)
+func (vp *VideoProcessor) SetTranscriptStore(transcripts types.TranscriptStore) {
+	vp.transcripts = transcripts
+}
+
+// SetThumbnailCache enables local thumbnail caching when summaries are saved
+func (vp *VideoProcessor) SetThumbnailCache(thumbnails types.ThumbnailCache) {
+	vp.thumbnails = thumbnails
+}
+
+// UpdateConfig updates the processor configuration; it fails while a run is in progress
+func (vp *VideoProcessor) UpdateConfig(cfg types.Config) error {
+	vp.runMu.Lock()
+	defer vp.runMu.Unlock()
+	if vp.running {
+		return fmt.Errorf("cannot update configuration while videos are being processed")
+	}
+
+	config.ApplyAutoDefaults(&cfg)
+	vp.config = &cfg
+	vp.transcriptSem = make(chan struct{}, cfg.Processing.MaxConcurrentTranscripts)
+	vp.aiSem = make(chan struct{}, cfg.Processing.MaxConcurrentVideos)
+	vp.boilerplate = newBoilerplateFilter(cfg.AI)
 	vp.logger.Info("Updated processor configuration")
 	return nil
 }
 
+// maxSummaryIDAttempts bounds how often a colliding summary ID is regenerated
+const maxSummaryIDAttempts = 5
+
+// saveSummary saves a summary, regenerating its ID if storage reports a collision
+func (vp *VideoProcessor) saveSummary(ctx context.Context, summary *types.Summary) error {
+	var err error
+	for attempt := 1; attempt <= maxSummaryIDAttempts; attempt++ {
+		err = vp.storage.SaveSummary(ctx, *summary)
+		if !errors.Is(err, types.ErrDuplicateSummaryID) {
+			return err
+		}
+
+		vp.logger.Warn("Summary ID collision, regenerating", "summaryID", summary.ID, "attempt", attempt)
+		summary.ID = vp.generateSummaryID()
+	}
+	return err
+}
+
 // generateSummaryID generates a unique ID for a summary
 func (vp *VideoProcessor) generateSummaryID() string {
 	bytes := make([]byte, 8)
@@ -264,11 +1048,60 @@ func (vp *VideoProcessor) GetSummaryStats(ctx context.Context) (map[string]inter
 	stats := map[string]interface{}{
 		"pending_summaries": len(pendingSummaries),
 		"last_check":        time.Now().Format("2006-01-02 15:04:05"),
+		"api_calls":         vp.APICalls(),
 	}
 
 	return stats, nil
 }
 
+// RunReport returns what the last ProcessNewVideos run did, with the API usage so far
+func (vp *VideoProcessor) RunReport() RunReport {
+	report := RunReport{StartedAt: time.Now()}
+	if vp.run != nil {
+		report = vp.run.snapshot()
+	}
+	report.APICalls = vp.APICalls()
+	return report
+}
+
+// RecordSuccessfulRun stores when the run started for -since-last-run. A run where a
+// video or channel failed isn't recorded, so the next run asks for those videos again.
+func (vp *VideoProcessor) RecordSuccessfulRun(ctx context.Context) error {
+	report := vp.RunReport()
+	if len(report.Failed) > 0 || len(report.FailedChannels) > 0 {
+		vp.logger.Info("Not recording this run as successful since some videos or channels failed",
+			"failedVideos", len(report.Failed), "failedChannels", len(report.FailedChannels))
+		return nil
+	}
+	if err := vp.storage.SetLastSuccessfulRun(ctx, report.StartedAt); err != nil {
+		return fmt.Errorf("failed to record successful run: %w", err)
+	}
+	return nil
+}
+
+// APICalls returns the requests made so far by each API client that counts them
+func (vp *VideoProcessor) APICalls() []types.APICallCount {
+	var counts []types.APICallCount
+	for _, client := range []interface{}{vp.youtubeClient, vp.transcriptClient, vp.aiClient} {
+		if counter, ok := client.(types.CallCounter); ok {
+			counts = append(counts, counter.APICalls()...)
+		}
+	}
+	return counts
+}
+
+// FormatAPICalls renders call counts for the run log, e.g. "YouTube: 14 calls, Claude: 9 calls"
+func FormatAPICalls(counts []types.APICallCount) string {
+	parts := make([]string, len(counts))
+	for i, count := range counts {
+		parts[i] = fmt.Sprintf("%s: %d calls", count.API, count.Calls)
+		if count.Tokens > 0 {
+			parts[i] += fmt.Sprintf(" (%d tokens)", count.Tokens)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // ProcessPendingSummariesForEmail processes summaries that are ready to be sent via email
 func (vp *VideoProcessor) ProcessPendingSummariesForEmail(ctx context.Context) ([]types.Summary, error) {
 	summaries, err := vp.storage.GetPendingSummaries(ctx)
@@ -277,5 +1110,37 @@ func (vp *VideoProcessor) ProcessPendingSummariesForEmail(ctx context.Context) (
 	}
 
 	vp.logger.Info("Retrieved pending summaries for email", "count", len(summaries))
+
+	if vp.config.Email.VerifyVideos {
+		return vp.dropRemovedVideos(ctx, summaries)
+	}
 	return summaries, nil
 }
+
+// dropRemovedVideos filters out summaries whose video was deleted or made private,
+// marking them "Removed" so they aren't offered for the digest again. Videos that
+// can't be checked (e.g. network errors) are kept.
+func (vp *VideoProcessor) dropRemovedVideos(ctx context.Context, summaries []types.Summary) ([]types.Summary, error) {
+	var available []types.Summary
+	var removedIDs []string
+	for _, summary := range summaries {
+		_, err := vp.youtubeClient.GetVideoDetails(ctx, summary.VideoID)
+		switch {
+		case errors.Is(err, types.ErrVideoNotFound):
+			vp.logger.Info("Video no longer available, dropping from digest", "videoID", summary.VideoID, "title", summary.VideoTitle)
+			removedIDs = append(removedIDs, summary.ID)
+			continue
+		case isFatalAPIError(err):
+			vp.logger.Warn("Cannot verify videos, sending digest unverified", "error", err)
+			return summaries, nil
+		case err != nil:
+			vp.logger.Warn("Failed to verify video, keeping it", "videoID", summary.VideoID, "error", err)
+		}
+		available = append(available, summary)
+	}
+
+	if err := vp.storage.UpdateSummaryStatus(ctx, removedIDs, "Removed"); err != nil {
+		return nil, fmt.Errorf("failed to mark removed videos: %w", err)
+	}
+	return available, nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"youtube-summarizer/internal/clients"
+	"youtube-summarizer/internal/config"
+	"youtube-summarizer/internal/storage"
+	"youtube-summarizer/pkg/types"
+)
+
+// nopLogger discards all log output
+type nopLogger struct{}
+
+func (nopLogger) Info(msg string, fields ...interface{})             {}
+func (nopLogger) Error(msg string, err error, fields ...interface{}) {}
+func (nopLogger) Debug(msg string, fields ...interface{})            {}
+func (nopLogger) Warn(msg string, fields ...interface{})             {}
+
+// newMockProcessor wires a processor to the mock clients and the given storage
+func newMockProcessor(store types.Storage) *VideoProcessor {
+	cfg := config.DefaultConfig()
+	config.ApplyAutoDefaults(cfg)
+	logger := nopLogger{}
+	return NewVideoProcessor(store,
+		clients.NewMockYouTubeClient(logger),
+		clients.NewMockTranscriptClient(logger),
+		clients.NewMockAIClient(logger),
+		cfg, logger)
+}
+
+func TestProcessNewVideosWithMocks(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage(types.Channel{ID: "UCmock", Name: "Mock Channel"})
+	processor := newMockProcessor(store)
+
+	if err := processor.ProcessNewVideos(ctx); err != nil {
+		t.Fatalf("ProcessNewVideos() error = %v", err)
+	}
+
+	summaries, err := store.GetPendingSummaries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := processor.config.YouTube.MaxVideosPerChannel
+	if len(summaries) != want {
+		t.Fatalf("got %d pending summaries, want %d", len(summaries), want)
+	}
+
+	for _, summary := range summaries {
+		if summary.Summary == "" {
+			t.Errorf("summary for %s is empty", summary.VideoID)
+		}
+		if summary.Status != "New" {
+			t.Errorf("summary for %s has status %q, want New", summary.VideoID, summary.Status)
+		}
+		processed, err := store.IsVideoProcessed(ctx, summary.VideoID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !processed {
+			t.Errorf("video %s was not marked processed", summary.VideoID)
+		}
+	}
+
+	// A second run finds nothing new
+	if err := processor.ProcessNewVideos(ctx); err != nil {
+		t.Fatalf("second ProcessNewVideos() error = %v", err)
+	}
+	again, err := store.GetPendingSummaries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != len(summaries) {
+		t.Errorf("second run left %d pending summaries, want %d", len(again), len(summaries))
+	}
+}
+
+func TestSaveSummaryRegeneratesCollidingID(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage()
+	processor := newMockProcessor(store)
+
+	if err := store.SaveSummary(ctx, types.Summary{ID: "sum_taken", VideoID: "first", Status: "New"}); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := types.Summary{ID: "sum_taken", VideoID: "second", Status: "New"}
+	if err := processor.saveSummary(ctx, &summary); err != nil {
+		t.Fatalf("saveSummary() error = %v", err)
+	}
+	if summary.ID == "sum_taken" {
+		t.Fatal("saveSummary() kept the colliding ID")
+	}
+
+	summaries, err := store.GetPendingSummaries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("got %d summaries, want 2", len(summaries))
+	}
+	for _, saved := range summaries {
+		if saved.VideoID == "second" && saved.ID != summary.ID {
+			t.Errorf("stored ID = %q, want regenerated ID %q", saved.ID, summary.ID)
+		}
+	}
+}
+
+func TestDurationInBand(t *testing.T) {
+	const (
+		minDuration = 2 * time.Minute
+		maxDuration = 60 * time.Minute
+	)
+	tests := []struct {
+		name               string
+		length, minD, maxD time.Duration
+		want               bool
+	}{
+		{"no bounds", 0, 0, 0, true},
+		{"below min", minDuration - time.Second, minDuration, maxDuration, false},
+		{"at min", minDuration, minDuration, maxDuration, true},
+		{"inside band", 10 * time.Minute, minDuration, maxDuration, true},
+		{"at max", maxDuration, minDuration, maxDuration, true},
+		{"above max", maxDuration + time.Second, minDuration, maxDuration, false},
+		{"open max", 10 * time.Hour, minDuration, 0, true},
+		{"open min", 0, 0, maxDuration, true},
+		{"equal bounds", minDuration, minDuration, minDuration, true},
+	}
+
+	for _, tt := range tests {
+		if got := durationInBand(tt.length, tt.minD, tt.maxD); got != tt.want {
+			t.Errorf("%s: durationInBand(%v, %v, %v) = %v, want %v", tt.name, tt.length, tt.minD, tt.maxD, got, tt.want)
+		}
+	}
+}
+
+// countingTranscriptClient counts the transcripts fetched through it
+type countingTranscriptClient struct {
+	types.TranscriptClient
+	fetches int
+}
+
+func (c *countingTranscriptClient) GetTranscriptWithThumbnail(ctx context.Context, videoID string) (*types.TranscriptData, error) {
+	c.fetches++
+	return c.TranscriptClient.GetTranscriptWithThumbnail(ctx, videoID)
+}
+
+// flakyAIClient fails its first failures calls with a transient error
+type flakyAIClient struct {
+	types.AIClient
+	failures int
+}
+
+func (c *flakyAIClient) SummarizeWithPrompt(ctx context.Context, promptTemplate, transcript, title string) (string, error) {
+	if c.failures > 0 {
+		c.failures--
+		return "", fmt.Errorf("overloaded: %w", types.ErrServerError)
+	}
+	return c.AIClient.SummarizeWithPrompt(ctx, promptTemplate, transcript, title)
+}
+
+func TestProcessVideoRetryReusesTranscript(t *testing.T) {
+	processor := newMockProcessor(storage.NewMemoryStorage())
+	transcripts := &countingTranscriptClient{TranscriptClient: processor.transcriptClient}
+	processor.transcriptClient = transcripts
+	processor.aiClient = &flakyAIClient{AIClient: processor.aiClient, failures: 1}
+	processor.config.Processing.VideoRetries = 1
+	processor.config.Processing.VideoRetryDelay = time.Millisecond
+
+	summary, err := processor.ProcessVideo(context.Background(), "abc", false)
+	if err != nil {
+		t.Fatalf("ProcessVideo() error = %v", err)
+	}
+	if summary.Summary == "" {
+		t.Error("ProcessVideo() returned an empty summary")
+	}
+	if transcripts.fetches != 1 {
+		t.Errorf("transcript fetched %d times, want 1", transcripts.fetches)
+	}
+}
+
+func TestUpdateConfigResolvesAutoConcurrency(t *testing.T) {
+	processor := newMockProcessor(storage.NewMemoryStorage())
+
+	cfg := *processor.config
+	cfg.Processing.MaxConcurrentVideos = 0
+	if err := processor.UpdateConfig(cfg); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	if processor.config.Processing.MaxConcurrentVideos <= 0 || cap(processor.aiSem) != processor.config.Processing.MaxConcurrentVideos {
+		t.Errorf("max_concurrent_videos = %d with an AI semaphore of %d, want the auto default for both",
+			processor.config.Processing.MaxConcurrentVideos, cap(processor.aiSem))
+	}
+
+	// The semaphores stay put while a run is using them
+	processor.running = true
+	aiSem := processor.aiSem
+	if err := processor.UpdateConfig(cfg); err == nil {
+		t.Error("UpdateConfig() during a run succeeded, want an error")
+	}
+	if processor.aiSem != aiSem {
+		t.Error("UpdateConfig() during a run replaced the AI semaphore")
+	}
+}
+
+// failingChannelYouTubeClient fails to list the videos of one channel
+type failingChannelYouTubeClient struct {
+	types.YouTubeClient
+	channelID string
+}
+
+func (c *failingChannelYouTubeClient) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter time.Time) ([]types.Video, error) {
+	if channelID == c.channelID {
+		return nil, fmt.Errorf("listing failed: %w", types.ErrServerError)
+	}
+	return c.YouTubeClient.GetChannelVideos(ctx, channelID, maxResults, publishedAfter)
+}
+
+func TestFailedChannelKeepsLastSuccessfulRun(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage(types.Channel{ID: "UCgood", Name: "Good"}, types.Channel{ID: "UCbad", Name: "Bad"})
+	processor := newMockProcessor(store)
+	processor.youtubeClient = &failingChannelYouTubeClient{YouTubeClient: processor.youtubeClient, channelID: "UCbad"}
+
+	if err := processor.ProcessNewVideos(ctx); err != nil {
+		t.Fatalf("ProcessNewVideos() error = %v", err)
+	}
+	if failed := processor.RunReport().FailedChannels; len(failed) != 1 || failed[0] != "UCbad" {
+		t.Errorf("FailedChannels = %v, want [UCbad]", failed)
+	}
+	if err := processor.RecordSuccessfulRun(ctx); err != nil {
+		t.Fatalf("RecordSuccessfulRun() error = %v", err)
+	}
+	if lastRun, err := store.GetLastSuccessfulRun(ctx); err != nil || !lastRun.IsZero() {
+		t.Errorf("GetLastSuccessfulRun() = %v, %v, want it unset after a channel failed", lastRun, err)
+	}
+
+	// Once every channel lists its videos the run is recorded
+	processor.youtubeClient = processor.youtubeClient.(*failingChannelYouTubeClient).YouTubeClient
+	if err := processor.ProcessNewVideos(ctx); err != nil {
+		t.Fatalf("second ProcessNewVideos() error = %v", err)
+	}
+	if err := processor.RecordSuccessfulRun(ctx); err != nil {
+		t.Fatalf("RecordSuccessfulRun() error = %v", err)
+	}
+	if lastRun, err := store.GetLastSuccessfulRun(ctx); err != nil || lastRun.IsZero() {
+		t.Errorf("GetLastSuccessfulRun() = %v, %v, want the second run recorded", lastRun, err)
+	}
+}
+
+func TestUpdateConfigRebuildsBoilerplateFilter(t *testing.T) {
+	processor := newMockProcessor(storage.NewMemoryStorage())
+	content := videoContent{transcript: "Welcome back. Smash that like button. Today we cover Go.", fromTranscript: true}
+
+	cfg := *processor.config
+	cfg.AI.StripPhrases = []string{"smash that like button."}
+	if err := processor.UpdateConfig(cfg); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+
+	transcript, _ := processor.prepareTranscript(types.Video{ID: "abc"}, content)
+	if strings.Contains(strings.ToLower(transcript), "like button") {
+		t.Errorf("prepareTranscript() = %q, want the updated strip phrase removed", transcript)
+	}
+}
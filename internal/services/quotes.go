@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// maxQuotes caps the quotes kept per video
+const maxQuotes = 3
+
+// quotesPrompt asks for verbatim quotes, one per line, or NONE when nothing stands out
+const quotesPrompt = `Video Title: "{title}". From the following video transcript, pick 2-3 notable quotes worth remembering.
+Copy each quote verbatim from the transcript and put each on its own line starting with "> ".
+If nothing in the transcript is worth quoting, reply with NONE.
+Respond with the quotes only.
+
+Transcript:
+{transcript}`
+
+// extractQuotes asks the AI for a few verbatim quotes from the transcript
+// (ai.extract_quotes), reusing cached quotes when the summary cache is enabled.
+// Failures are logged and yield no quotes, so they never cost the summary.
+func (vp *VideoProcessor) extractQuotes(ctx context.Context, transcript string, video types.Video) []string {
+	if vp.summaryCache != nil {
+		if cached, ok := vp.summaryCache.Get(video.ID, quotesPrompt); ok {
+			return parseQuotes(cached)
+		}
+	}
+
+	var response string
+	var err error
+	if pc, ok := vp.aiClient.(types.ProviderAIClient); ok {
+		// Few-shot examples show summaries, not quotes, so they're left out
+		response, _, err = pc.SummarizeWithProvider(ctx, quotesPrompt, transcript, video.Title, nil)
+	} else {
+		response, err = vp.aiClient.SummarizeWithPrompt(ctx, quotesPrompt, transcript, video.Title)
+	}
+	if err != nil {
+		vp.logger.Warn("Failed to extract quotes", "videoID", video.ID, "error", err)
+		return nil
+	}
+
+	if vp.summaryCache != nil {
+		if err := vp.summaryCache.Put(video.ID, quotesPrompt, response); err != nil {
+			vp.logger.Warn("Failed to cache quotes", "videoID", video.ID, "error", err)
+		}
+	}
+
+	quotes := parseQuotes(response)
+	vp.logger.Debug("Extracted quotes", "videoID", video.ID, "count", len(quotes))
+	return quotes
+}
+
+// parseQuotes reads the "> " lines of a quotes response, dropping surrounding
+// quotation marks; a response without any (e.g. NONE) yields no quotes
+func parseQuotes(response string) []string {
+	var quotes []string
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, ">") {
+			continue
+		}
+		quote := strings.TrimSpace(strings.TrimPrefix(line, ">"))
+		quote = strings.TrimSpace(strings.Trim(quote, `"“”`))
+		if quote == "" {
+			continue
+		}
+		quotes = append(quotes, quote)
+		if len(quotes) == maxQuotes {
+			break
+		}
+	}
+	return quotes
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// ResummarizeCutoff selects stored summaries to regenerate: those written by a
+// model other than Model, or those created before Before
+type ResummarizeCutoff struct {
+	Model  string
+	Before time.Time
+}
+
+// ParseResummarizeCutoff reads a -resummarize-model-before value: a date
+// (2006-01-02) selects summaries created before it, anything else is taken as
+// the current model name and selects summaries written by any other model
+func ParseResummarizeCutoff(value string) (ResummarizeCutoff, error) {
+	if value == "" {
+		return ResummarizeCutoff{}, fmt.Errorf("resummarize cutoff cannot be empty")
+	}
+	if before, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return ResummarizeCutoff{Before: before}, nil
+	}
+	return ResummarizeCutoff{Model: value}, nil
+}
+
+// matches reports whether a summary falls under the cutoff. Skipped and removed
+// summaries are never regenerated.
+func (c ResummarizeCutoff) matches(summary types.Summary) bool {
+	if summary.Status == "Skipped" || summary.Status == "Removed" || summary.Summary == "" {
+		return false
+	}
+	if !c.Before.IsZero() {
+		return summary.CreatedAt.Before(c.Before)
+	}
+	return summary.Model != c.Model
+}
+
+// ResummarizeOlder regenerates the summaries selected by cutoff in place, keeping
+// their ID, status and creation time, and returns how many were updated.
+// A video that fails is logged and left as it was.
+func (vp *VideoProcessor) ResummarizeOlder(ctx context.Context, cutoff ResummarizeCutoff) (int, error) {
+	summaries, _, err := vp.storage.GetAllSummaries(ctx, 0, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get summaries: %w", err)
+	}
+
+	var selected []types.Summary
+	for _, summary := range summaries {
+		if cutoff.matches(summary) {
+			selected = append(selected, summary)
+		}
+	}
+	vp.logger.Info("Regenerating older summaries", "count", len(selected), "total", len(summaries))
+
+	updated := 0
+	for _, summary := range selected {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+		if err := vp.resummarize(ctx, summary); err != nil {
+			vp.logger.Error("Failed to regenerate summary", err, "summaryID", summary.ID, "videoID", summary.VideoID)
+			continue
+		}
+		updated++
+	}
+
+	vp.logger.Info("Regenerated summaries", "updated", updated, "failed", len(selected)-updated)
+	return updated, nil
+}
+
+// resummarize replaces a stored summary, bypassing the summary cache so the
+// current model is used. A saved transcript is reused; otherwise the video's
+// content is fetched again.
+func (vp *VideoProcessor) resummarize(ctx context.Context, record types.Summary) error {
+	video, content, err := vp.resummarizeContent(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	transcript, wordCount := vp.prepareTranscript(*video, content)
+	_, prompt := vp.selectPrompt(video.Title)
+
+	vp.aiSem <- struct{}{}
+	summary, provider, err := vp.summarize(ctx, prompt, transcript, video.Title)
+	if err == nil && vp.config.AI.ExtractQuotes {
+		record.Quotes = vp.extractQuotes(ctx, transcript, *video)
+	}
+	<-vp.aiSem
+	if err != nil {
+		return fmt.Errorf("failed to generate summary: %w", err)
+	}
+
+	if vp.summaryCache != nil {
+		if err := vp.summaryCache.Put(video.ID, prompt, summary); err != nil {
+			vp.logger.Warn("Failed to cache summary", "videoID", video.ID, "error", err)
+		}
+	}
+
+	record.Summary = summary
+	record.Provider = provider
+	record.Model = vp.providerModel(provider)
+	record.Source = content.source
+	record.TranscriptLang = content.lang
+	record.WordCount = wordCount
+	record.ReadingMinutes = types.EstimateReadingMinutes(summary)
+	record.Audience = vp.config.AI.Audience
+
+	if err := vp.storage.UpdateSummary(ctx, record); err != nil {
+		return fmt.Errorf("failed to update summary: %w", err)
+	}
+
+	vp.logger.Info("Regenerated summary", "videoID", record.VideoID, "provider", provider, "model", record.Model)
+	return nil
+}
+
+// resummarizeContent returns the video and text to summarize again, preferring a
+// transcript saved by storage.save_transcripts over fetching it
+func (vp *VideoProcessor) resummarizeContent(ctx context.Context, record types.Summary) (*types.Video, videoContent, error) {
+	if vp.transcripts != nil {
+		if transcript, ok := vp.transcripts.Get(record.VideoID); ok {
+			vp.logger.Debug("Using saved transcript", "videoID", record.VideoID)
+			video := &types.Video{
+				ID:          record.VideoID,
+				Title:       record.VideoTitle,
+				ChannelName: record.ChannelName,
+				URL:         record.VideoURL,
+				PublishedAt: record.PublishedAt,
+			}
+			source := record.Source
+			if source == "" || source == types.SourceDescription {
+				source = types.SourceTranscript
+			}
+			return video, videoContent{
+				transcript:     transcript,
+				thumbnailURL:   record.ThumbnailURL,
+				fromTranscript: true,
+				source:         source,
+				lang:           record.TranscriptLang,
+			}, nil
+		}
+	}
+
+	video, err := vp.youtubeClient.GetVideoDetails(ctx, record.VideoID)
+	if err != nil {
+		return nil, videoContent{}, fmt.Errorf("failed to get video details: %w", err)
+	}
+
+	vp.transcriptSem <- struct{}{}
+	content := vp.fetchVideoContent(ctx, *video)
+	<-vp.transcriptSem
+
+	if content.transcriptMissing {
+		return nil, videoContent{}, fmt.Errorf("video no longer has a transcript")
+	}
+	if vp.transcripts != nil && content.fromTranscript {
+		if err := vp.transcripts.Put(video.ID, content.transcript); err != nil {
+			vp.logger.Warn("Failed to save transcript", "videoID", video.ID, "error", err)
+		}
+	}
+	return video, content, nil
+}
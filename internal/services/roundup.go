@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// roundupThemesPrompt asks for a top-themes overview of the week; {transcript} receives the summaries
+const roundupThemesPrompt = `Below are summaries of the YouTube videos from the past week.
+
+{transcript}
+
+List the 3-5 top themes across these videos as short bullet points, each with one sentence of context. Respond with the bullet points only.`
+
+// ChannelGroup is one channel's summaries in the weekly roundup
+type ChannelGroup struct {
+	ChannelName string
+	Summaries   []types.Summary
+}
+
+// RoundupData represents the data passed to the weekly roundup template
+type RoundupData struct {
+	StartDate  string
+	EndDate    string
+	Themes     string
+	Channels   []ChannelGroup
+	TotalCount int
+}
+
+// SendWeeklyRoundup emails the given week's summaries grouped by channel.
+// Unlike SendDigest it is read-only: summary statuses are left untouched.
+func (es *EmailService) SendWeeklyRoundup(ctx context.Context, summaries []types.Summary, start, end time.Time) error {
+	if len(summaries) == 0 {
+		es.logger.Info("No summaries in the past week, skipping weekly roundup")
+		return nil
+	}
+
+	es.logger.Info("Preparing weekly roundup", "summaryCount", len(summaries))
+
+	data := RoundupData{
+		StartDate:  start.Format("January 2"),
+		EndDate:    end.Format("January 2, 2006"),
+		Channels:   groupByChannel(summaries),
+		TotalCount: len(summaries),
+	}
+
+	// Optionally produce a top-themes overview (costs one extra AI call)
+	if es.config.Email.RoundupThemes {
+		themes, err := es.generateThemes(ctx, summaries)
+		if err != nil {
+			es.logger.Warn("Failed to generate weekly themes, sending without them", "error", err)
+		} else {
+			data.Themes = themes
+		}
+	}
+
+	tmpl, err := template.New("roundup").Funcs(emailTemplateFuncs(es.config)).Parse(roundupEmailTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse roundup template: %w", err)
+	}
+
+	var body strings.Builder
+	if err := tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute roundup template: %w", err)
+	}
+
+	subject := fmt.Sprintf("YouTube Week in Review - %s to %s", data.StartDate, data.EndDate)
+	if err := es.sendEmail(es.config.Email.Recipients, subject, body.String(), "", nil); err != nil {
+		return fmt.Errorf("failed to send weekly roundup: %w", err)
+	}
+
+	es.logger.Info("Successfully sent weekly roundup", "summaryCount", len(summaries), "channelCount", len(data.Channels))
+	return nil
+}
+
+// generateThemes makes one AI call over the week's summaries to list the top themes
+func (es *EmailService) generateThemes(ctx context.Context, summaries []types.Summary) (string, error) {
+	if es.aiClient == nil {
+		return "", fmt.Errorf("no AI client configured for weekly themes")
+	}
+
+	var combined strings.Builder
+	for _, summary := range summaries {
+		fmt.Fprintf(&combined, "%s (%s): %s\n\n", summary.VideoTitle, summary.ChannelName, summary.Summary)
+	}
+
+	return es.aiClient.SummarizeWithPrompt(ctx, roundupThemesPrompt, combined.String(), "Weekly themes")
+}
+
+// groupByChannel groups summaries by channel name, newest first within each channel
+func groupByChannel(summaries []types.Summary) []ChannelGroup {
+	indexByChannel := make(map[string]int)
+	var groups []ChannelGroup
+	for _, summary := range summaries {
+		i, ok := indexByChannel[summary.ChannelName]
+		if !ok {
+			i = len(groups)
+			indexByChannel[summary.ChannelName] = i
+			groups = append(groups, ChannelGroup{ChannelName: summary.ChannelName})
+		}
+		groups[i].Summaries = append(groups[i].Summaries, summary)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].ChannelName) < strings.ToLower(groups[j].ChannelName)
+	})
+	for _, group := range groups {
+		sort.Slice(group.Summaries, func(i, j int) bool {
+			return group.Summaries[i].PublishedAt.After(group.Summaries[j].PublishedAt)
+		})
+	}
+	return groups
+}
+
+// roundupEmailTemplate is the HTML template for the weekly roundup email
+const roundupEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>YouTube Week in Review</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif;
+            line-height: 1.6;
+            color: #1C1B1F;
+            max-width: 900px;
+            margin: 0 auto;
+            padding: 20px;
+            background: #F6F3EB;
+        }
+        .header {
+            background: linear-gradient(135deg, #630D5F 0%, #B37BA4 100%);
+            color: #FEFFC4;
+            text-align: center;
+            padding: 30px;
+            border-radius: 16px;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 2.2em;
+        }
+        .themes {
+            background: rgba(254, 255, 196, 0.5);
+            border-left: 5px solid #BFA359;
+            padding: 15px 20px;
+            margin: 25px 0;
+            white-space: pre-line;
+        }
+        .channel h2 {
+            color: #630D5F;
+            border-bottom: 2px solid #B37BA4;
+            padding-bottom: 5px;
+        }
+        .video {
+            margin-bottom: 20px;
+        }
+        .video a {
+            color: #630D5F;
+            font-weight: 600;
+            text-decoration: none;
+        }
+        .video .meta {
+            color: #6B6B6B;
+            font-size: 0.9em;
+        }
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>📅 Week in Review</h1>
+        <p>{{.StartDate}} – {{.EndDate}} · {{.TotalCount}} videos from {{len .Channels}} channels</p>
+    </div>
+
+    {{if .Themes}}
+    <div class="themes">
+        <strong>Top themes</strong>
+        {{.Themes}}
+    </div>
+    {{end}}
+
+    {{range .Channels}}
+    <div class="channel">
+        <h2>{{.ChannelName}}</h2>
+        {{range .Summaries}}
+        <div class="video">
+            <a href="{{.VideoURL}}">{{.VideoTitle}}</a>
+            <div class="meta">Published {{.PublishedAt.Format "Mon, Jan 2"}}{{with duration .Duration}} · {{.}}{{end}}</div>
+            <div>{{summaryBody (clip .Summary)}}{{if clipped .Summary}} <a href="{{.VideoURL}}">… (read more)</a>{{end}}</div>
+        </div>
+        {{end}}
+    </div>
+    {{end}}
+</body>
+</html>`
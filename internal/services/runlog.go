@@ -0,0 +1,110 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// RunReport is the machine-readable record of one run written to -run-log
+type RunReport struct {
+	StartedAt      time.Time            `json:"started_at"`
+	FinishedAt     time.Time            `json:"finished_at"`
+	Channels       []string             `json:"channels"`
+	VideosFound    int                  `json:"videos_found"`
+	Summarized     []string             `json:"summarized"`
+	Skipped        []string             `json:"skipped"`
+	Failed         []string             `json:"failed"`
+	FailedChannels []string             `json:"failed_channels"`
+	APICalls       []types.APICallCount `json:"api_calls"`
+	EmailSent      bool                 `json:"email_sent"`
+	Error          string               `json:"error,omitempty"`
+}
+
+// runRecorder collects a RunReport from the concurrent channel and video goroutines
+type runRecorder struct {
+	mu     sync.Mutex
+	report RunReport
+}
+
+// newRunRecorder starts a report for a run beginning now
+func newRunRecorder() *runRecorder {
+	return &runRecorder{report: RunReport{
+		StartedAt:      time.Now(),
+		Channels:       []string{},
+		Summarized:     []string{},
+		Skipped:        []string{},
+		Failed:         []string{},
+		FailedChannels: []string{},
+	}}
+}
+
+// channel records a processed channel and how many videos it listed
+func (r *runRecorder) channel(channelID string, videosFound int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Channels = append(r.report.Channels, channelID)
+	r.report.VideosFound += videosFound
+}
+
+// summarized, skipped and failed record a video's outcome
+func (r *runRecorder) summarized(videoID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Summarized = append(r.report.Summarized, videoID)
+}
+
+func (r *runRecorder) skipped(videoID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Skipped = append(r.report.Skipped, videoID)
+}
+
+func (r *runRecorder) failed(videoID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Failed = append(r.report.Failed, videoID)
+}
+
+// channelFailed records a channel that couldn't be processed
+func (r *runRecorder) channelFailed(channelID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.FailedChannels = append(r.report.FailedChannels, channelID)
+}
+
+// snapshot returns a copy of the report so far
+func (r *runRecorder) snapshot() RunReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := r.report
+	report.Channels = append([]string{}, r.report.Channels...)
+	report.Summarized = append([]string{}, r.report.Summarized...)
+	report.Skipped = append([]string{}, r.report.Skipped...)
+	report.Failed = append([]string{}, r.report.Failed...)
+	report.FailedChannels = append([]string{}, r.report.FailedChannels...)
+	return report
+}
+
+// AppendRunLog appends the report to path as one JSON line (NDJSON)
+func AppendRunLog(path string, report RunReport) error {
+	line, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open run log %s: %w", path, err)
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write run log %s: %w", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimestampFormat sorts lexically in chronological order
+const backupTimestampFormat = "20060102-150405"
+
+// Backup copies the Excel file to <name>.<timestamp>.bak.xlsx next to the original
+// and removes the oldest backups so that at most keep backups remain.
+// It is a no-op when the data file does not exist yet.
+func (es *ExcelStorage) Backup(keep int) error {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	if keep <= 0 {
+		return nil
+	}
+
+	if _, err := os.Stat(es.filePath); os.IsNotExist(err) {
+		es.logger.Debug("No Excel file to back up yet", "path", es.filePath)
+		return nil
+	}
+
+	backupPath := fmt.Sprintf("%s.%s.bak%s", es.backupPrefix(), time.Now().Format(backupTimestampFormat), filepath.Ext(es.filePath))
+	if err := copyFile(es.filePath, backupPath); err != nil {
+		return fmt.Errorf("failed to back up Excel file: %w", err)
+	}
+
+	es.logger.Info("Backed up Excel file", "backup", backupPath)
+
+	return es.pruneBackups(keep)
+}
+
+// ListBackups returns existing backup files, oldest first
+func (es *ExcelStorage) ListBackups() ([]string, error) {
+	pattern := es.backupPrefix() + ".*.bak" + filepath.Ext(es.filePath)
+	backups, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// pruneBackups removes the oldest backups beyond the keep limit
+func (es *ExcelStorage) pruneBackups(keep int) error {
+	backups, err := es.ListBackups()
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > keep {
+		if err := os.Remove(backups[0]); err != nil {
+			return fmt.Errorf("failed to remove old backup %s: %w", backups[0], err)
+		}
+		es.logger.Debug("Removed old backup", "backup", backups[0])
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// backupPrefix returns the data file path without its extension
+func (es *ExcelStorage) backupPrefix() string {
+	return strings.TrimSuffix(es.filePath, filepath.Ext(es.filePath))
+}
+
+// copyFile copies src to dst, replacing dst if it exists
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
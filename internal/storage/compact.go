@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Compact rewrites the workbook with every sheet's rows packed together: blank rows
+// and trailing empty cells are dropped, rows move up to fill the gaps and the known
+// sheets get their headers back if any went missing. Sheets added by hand are kept.
+// Only values are copied, so cell formatting is reset.
+func (es *ExcelStorage) Compact(ctx context.Context) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	source, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer source.Close()
+
+	file := excelize.NewFile()
+	defer file.Close()
+	defaultSheets := file.GetSheetList()
+
+	sourceSheets := source.GetSheetList()
+	removed := 0
+	for _, sheet := range sourceSheets {
+		rows, err := source.GetRows(sheet)
+		if err != nil {
+			return fmt.Errorf("failed to get rows from sheet %s: %w", sheet, err)
+		}
+
+		var header []string
+		var kept [][]string
+		if len(rows) > 0 {
+			header = trimRow(rows[0])
+		}
+		for i := 1; i < len(rows); i++ {
+			row := trimRow(rows[i])
+			if len(row) == 0 {
+				removed++
+				continue
+			}
+			kept = append(kept, row)
+		}
+
+		if _, err := file.NewSheet(sheet); err != nil {
+			return fmt.Errorf("failed to create sheet %s: %w", sheet, err)
+		}
+		if err := writeRecoveredRows(file, sheet, header, kept); err != nil {
+			return err
+		}
+	}
+
+	for _, sheet := range repairSheets {
+		if err := es.ensureSheet(file, sheet.name, sheet.headers); err != nil {
+			return err
+		}
+	}
+
+	for _, sheetName := range defaultSheets {
+		if containsSheet(sourceSheets, sheetName) {
+			continue
+		}
+		if err := file.DeleteSheet(sheetName); err != nil {
+			return fmt.Errorf("failed to delete default sheet %s: %w", sheetName, err)
+		}
+	}
+	if index, err := file.GetSheetIndex(ChannelsSheet); err == nil && index >= 0 {
+		file.SetActiveSheet(index)
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return err
+	}
+
+	es.processedMu.Lock()
+	es.processedIDs = nil
+	es.processedMu.Unlock()
+
+	es.logger.Info("Compacted Excel file", "path", es.filePath, "removedRows", removed)
+	return nil
+}
+
+// trimRow drops a row's trailing blank cells; an empty result means the row is blank
+func trimRow(row []string) []string {
+	end := len(row)
+	for end > 0 && strings.TrimSpace(row[end-1]) == "" {
+		end--
+	}
+	return row[:end]
+}
+
+// containsSheet reports whether sheets includes name
+func containsSheet(sheets []string, name string) bool {
+	for _, sheet := range sheets {
+		if sheet == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Compact is a no-op: memory storage has no gaps to remove
+func (ms *MemoryStorage) Compact(ctx context.Context) error {
+	return nil
+}
@@ -3,6 +3,11 @@ package storage
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"youtube-summarizer/pkg/types"
@@ -14,24 +19,90 @@ import (
 type ExcelStorage struct {
 	filePath string
 	logger   types.Logger
+
+	// Save retries for when the file is locked (e.g. open in Excel on Windows)
+	saveAttempts  int
+	saveBaseDelay time.Duration
+
+	// latestCount, when positive, caps the Summaries sheet to the newest rows
+	latestCount int
+
+	// mu serializes access to the file: every write rewrites the whole workbook, so
+	// concurrent saves from the channel goroutines would overwrite each other's rows.
+	// Writers take it exclusively and readers shared, always before processedMu.
+	// The lock is per process only; it doesn't protect against another instance.
+	mu sync.RWMutex
+
+	// processedIDs caches the ProcessedVideos sheet so lookups don't reread the
+	// file; it is loaded on first use and kept in step with our own writes
+	processedMu  sync.Mutex
+	processedIDs map[string]bool
 }
 
 // NewExcelStorage creates a new Excel storage instance
 func NewExcelStorage(filePath string, logger types.Logger) *ExcelStorage {
 	return &ExcelStorage{
-		filePath: filePath,
-		logger:   logger,
+		filePath:      filePath,
+		logger:        logger,
+		saveAttempts:  1,
+		saveBaseDelay: time.Second,
+	}
+}
+
+// SetSaveRetry configures how many times a save is attempted and the initial
+// delay between attempts, which doubles after each failure
+func (es *ExcelStorage) SetSaveRetry(attempts int, baseDelay time.Duration) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	es.saveAttempts = attempts
+	es.saveBaseDelay = baseDelay
+}
+
+// SetLatestCount keeps only the newest count summaries, evicting the oldest sent
+// ones on each save; 0 keeps the full history
+func (es *ExcelStorage) SetLatestCount(count int) {
+	es.latestCount = count
+}
+
+// saveWithRetry saves the workbook, retrying with backoff while the file is locked
+func (es *ExcelStorage) saveWithRetry(file *excelize.File) error {
+	delay := es.saveBaseDelay
+	var err error
+	for attempt := 1; attempt <= es.saveAttempts; attempt++ {
+		if err = file.SaveAs(es.filePath); err == nil {
+			return nil
+		}
+
+		if attempt < es.saveAttempts {
+			es.logger.Warn("Failed to save Excel file, retrying", "error", err, "attempt", attempt, "delay", delay.String())
+			time.Sleep(delay)
+			delay *= 2
+		}
 	}
+
+	return fmt.Errorf("failed to save Excel file after %d attempts: %w", es.saveAttempts, err)
 }
 
 // Initialize creates the Excel file with proper structure if it doesn't exist
 func (es *ExcelStorage) Initialize() error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
 	// Try to open existing file
 	file, err := excelize.OpenFile(es.filePath)
+	var defaultSheets []string
 	if err != nil {
+		// Never replace a file that exists but can't be read
+		if _, statErr := os.Stat(es.filePath); !os.IsNotExist(statErr) {
+			return fmt.Errorf("failed to open Excel file %s (run with -repair to recover it): %w", es.filePath, err)
+		}
+
 		// File doesn't exist, create new one
 		es.logger.Info("Creating new Excel file", "path", es.filePath)
 		file = excelize.NewFile()
+		// Whatever excelize created before our sheets is a placeholder to remove
+		defaultSheets = file.GetSheetList()
 	}
 	defer file.Close()
 
@@ -48,13 +119,44 @@ func (es *ExcelStorage) Initialize() error {
 		return fmt.Errorf("failed to ensure summaries sheet: %w", err)
 	}
 
-	// Delete the default "Sheet1" if it exists and is empty
-	if sheetList := file.GetSheetList(); len(sheetList) > 3 {
-		for _, sheetName := range sheetList {
-			if sheetName == "Sheet1" {
-				file.DeleteSheet(sheetName)
-				break
-			}
+	// Channels of a workbook from before the channel history sheet have already been processed
+	historyIndex, _ := file.GetSheetIndex(ChannelHistorySheet)
+	backfillHistory := defaultSheets == nil && historyIndex < 0
+	if err := es.ensureSheet(file, ChannelHistorySheet, ChannelHistoryHeaders()); err != nil {
+		return fmt.Errorf("failed to ensure channel history sheet: %w", err)
+	}
+	if backfillHistory {
+		count, err := backfillChannelHistory(file)
+		if err != nil {
+			return fmt.Errorf("failed to backfill channel history: %w", err)
+		}
+		es.logger.Info("Backfilled channel history from processed videos", "channels", count)
+	}
+
+	if err := es.ensureSheet(file, ChannelActivitySheet, ChannelActivityHeaders()); err != nil {
+		return fmt.Errorf("failed to ensure channel activity sheet: %w", err)
+	}
+
+	if err := es.ensureSheet(file, StateSheet, StateHeaders()); err != nil {
+		return fmt.Errorf("failed to ensure state sheet: %w", err)
+	}
+
+	if err := es.ensureSheet(file, FailedVideosSheet, FailedVideoHeaders()); err != nil {
+		return fmt.Errorf("failed to ensure failed videos sheet: %w", err)
+	}
+
+	// Delete the placeholder sheets of a new workbook now that ours exist
+	for _, sheetName := range defaultSheets {
+		if sheetName == ChannelsSheet || sheetName == ProcessedVideosSheet || sheetName == SummariesSheet || sheetName == ChannelHistorySheet || sheetName == ChannelActivitySheet || sheetName == StateSheet || sheetName == FailedVideosSheet {
+			continue
+		}
+		if err := file.DeleteSheet(sheetName); err != nil {
+			return fmt.Errorf("failed to delete default sheet %s: %w", sheetName, err)
+		}
+	}
+	if len(defaultSheets) > 0 {
+		if index, err := file.GetSheetIndex(ChannelsSheet); err == nil && index >= 0 {
+			file.SetActiveSheet(index)
 		}
 	}
 
@@ -66,6 +168,52 @@ func (es *ExcelStorage) Initialize() error {
 	return nil
 }
 
+// backfillChannelHistory fills a new channel history sheet from ProcessedVideos, dating
+// each channel by its earliest processed video, so an upgraded workbook doesn't give every
+// channel the first-run limit again. It returns the number of channels recorded.
+func backfillChannelHistory(file *excelize.File) (int, error) {
+	rows, err := file.GetRows(ProcessedVideosSheet)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows from processed videos sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, ProcessedVideoHeaders())
+	firstProcessed := make(map[string]time.Time)
+	for i := 1; i < len(rows); i++ {
+		channelID := rowCell(rows[i], columns, "ChannelID")
+		if channelID == "" {
+			continue
+		}
+		// An unreadable date still marks the channel as seen
+		at, _ := time.ParseInLocation("2006-01-02 15:04:05", rowCell(rows[i], columns, "ProcessedAt"), time.Local)
+		if first, ok := firstProcessed[channelID]; !ok || (!at.IsZero() && (first.IsZero() || at.Before(first))) {
+			firstProcessed[channelID] = at
+		}
+	}
+
+	channelIDs := make([]string, 0, len(firstProcessed))
+	for channelID := range firstProcessed {
+		channelIDs = append(channelIDs, channelID)
+	}
+	sort.Strings(channelIDs)
+
+	historyColumns := headerColumns(ChannelHistoryHeaders())
+	now := time.Now()
+	for i, channelID := range channelIDs {
+		at := firstProcessed[channelID]
+		if at.IsZero() {
+			at = now
+		}
+		if err := writeRow(file, ChannelHistorySheet, i+2, historyColumns, map[string]interface{}{
+			"ChannelID":        channelID,
+			"FirstProcessedAt": at.Format("2006-01-02 15:04:05"),
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return len(channelIDs), nil
+}
+
 // ensureSheet creates a sheet with headers if it doesn't exist
 func (es *ExcelStorage) ensureSheet(file *excelize.File, sheetName string, headers []string) error {
 	// Check if sheet exists
@@ -85,22 +233,95 @@ func (es *ExcelStorage) ensureSheet(file *excelize.File, sheetName string, heade
 		}
 	}
 
-	// Add headers if the sheet is empty
-	cellValue, err := file.GetCellValue(sheetName, "A1")
-	if err != nil || cellValue == "" {
-		for i, header := range headers {
-			cell := fmt.Sprintf("%c1", 'A'+i)
-			if err := file.SetCellValue(sheetName, cell, header); err != nil {
-				return fmt.Errorf("failed to set header %s: %w", header, err)
-			}
+	// Append any missing headers after the existing ones, which upgrades sheets created
+	// before new columns were added without disturbing columns the user moved or inserted
+	rows, err := file.GetRows(sheetName)
+	if err != nil {
+		return fmt.Errorf("failed to read headers of sheet %s: %w", sheetName, err)
+	}
+	var existing []string
+	if len(rows) > 0 {
+		existing = rows[0]
+	}
+	columns := headerColumns(existing)
+
+	next := len(existing)
+	for _, header := range headers {
+		if _, ok := columns[header]; ok {
+			continue
+		}
+		cell, err := excelize.CoordinatesToCellName(next+1, 1)
+		if err != nil {
+			return fmt.Errorf("failed to place header %s: %w", header, err)
+		}
+		if err := file.SetCellValue(sheetName, cell, header); err != nil {
+			return fmt.Errorf("failed to set header %s: %w", header, err)
+		}
+		next++
+	}
+
+	return nil
+}
+
+// headerColumns maps each header name in a sheet's first row to its column index
+func headerColumns(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if _, ok := columns[name]; name != "" && !ok {
+			columns[name] = i
 		}
 	}
+	return columns
+}
+
+// sheetColumns returns a sheet's header columns, or the default layout of headers
+// for a sheet with no header row
+func sheetColumns(rows [][]string, headers []string) map[string]int {
+	if len(rows) == 0 {
+		return headerColumns(headers)
+	}
+	return headerColumns(rows[0])
+}
+
+// summaryColumns returns the Summaries sheet's header columns
+func summaryColumns(rows [][]string) map[string]int {
+	return sheetColumns(rows, SummaryHeaders())
+}
+
+// rowCell returns the value in the named column of a row, or "" when the row is short
+// or the sheet has no such column
+func rowCell(row []string, columns map[string]int, header string) string {
+	if i, ok := columns[header]; ok && i < len(row) {
+		return row[i]
+	}
+	return ""
+}
 
+// writeRow writes values to the given row of a sheet, placing each under its header
+// so reordered or inserted columns are left alone
+func writeRow(file *excelize.File, sheet string, rowNum int, columns map[string]int, values map[string]interface{}) error {
+	for header, value := range values {
+		i, ok := columns[header]
+		if !ok {
+			return fmt.Errorf("%s sheet has no %s column", sheet, header)
+		}
+		cell, err := excelize.CoordinatesToCellName(i+1, rowNum)
+		if err != nil {
+			return fmt.Errorf("failed to locate %s column: %w", header, err)
+		}
+		if err := file.SetCellValue(sheet, cell, value); err != nil {
+			return fmt.Errorf("failed to set cell %s: %w", cell, err)
+		}
+	}
 	return nil
 }
 
 // GetChannels retrieves all channels from Excel
 func (es *ExcelStorage) GetChannels(ctx context.Context) ([]types.Channel, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
 	file, err := excelize.OpenFile(es.filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open Excel file: %w", err)
@@ -112,20 +333,20 @@ func (es *ExcelStorage) GetChannels(ctx context.Context) ([]types.Channel, error
 		return nil, fmt.Errorf("failed to get rows from channels sheet: %w", err)
 	}
 
+	columns := sheetColumns(rows, ChannelHeaders())
 	var channels []types.Channel
 	// Skip header row (index 0)
 	for i := 1; i < len(rows); i++ {
 		row := rows[i]
-		if len(row) < 2 { // At least ID and Name required
-			continue
-		}
-
 		channel := types.Channel{
-			ID:   row[0],
-			Name: row[1],
+			ID:       rowCell(row, columns, "ID"),
+			Name:     rowCell(row, columns, "Name"),
+			Username: rowCell(row, columns, "Username"),
+			Group:    strings.TrimSpace(rowCell(row, columns, "Group")),
 		}
-		if len(row) > 2 {
-			channel.Username = row[2]
+		// At least ID and Name required
+		if channel.ID == "" || channel.Name == "" {
+			continue
 		}
 
 		channels = append(channels, channel)
@@ -135,18 +356,98 @@ func (es *ExcelStorage) GetChannels(ctx context.Context) ([]types.Channel, error
 	return channels, nil
 }
 
-// SaveSummary saves a summary to Excel
-func (es *ExcelStorage) SaveSummary(ctx context.Context, summary types.Summary) error {
+// AddChannel appends a channel to the Channels sheet with today's date as Added,
+// rejecting IDs that are already listed
+func (es *ExcelStorage) AddChannel(ctx context.Context, channel types.Channel) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if channel.ID == "" || channel.Name == "" {
+		return fmt.Errorf("channel ID and name are required")
+	}
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(ChannelsSheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from channels sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, ChannelHeaders())
+	for i, row := range rows {
+		if i > 0 && rowCell(row, columns, "ID") == channel.ID {
+			return fmt.Errorf("%w: %s", types.ErrDuplicateChannel, channel.ID)
+		}
+	}
+
+	excelChannel := FromChannel(channel)
+	if err := writeRow(file, ChannelsSheet, len(rows)+1, columns, map[string]interface{}{
+		"ID":       excelChannel.ID,
+		"Name":     excelChannel.Name,
+		"Username": excelChannel.Username,
+		"Added":    excelChannel.Added,
+		"Group":    excelChannel.Group,
+	}); err != nil {
+		return err
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return err
+	}
+
+	es.logger.Debug("Added channel to Excel", "channelID", channel.ID, "channelName", channel.Name)
+	return nil
+}
+
+// RemoveChannel deletes the channel's row from the Channels sheet. Its summaries,
+// processed videos and history are kept.
+func (es *ExcelStorage) RemoveChannel(ctx context.Context, channelID string) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
 	file, err := excelize.OpenFile(es.filePath)
 	if err != nil {
 		return fmt.Errorf("failed to open Excel file: %w", err)
 	}
-	defer func() {
-		if saveErr := file.SaveAs(es.filePath); saveErr != nil {
-			es.logger.Error("Failed to save Excel file", saveErr)
+	defer file.Close()
+
+	rows, err := file.GetRows(ChannelsSheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from channels sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, ChannelHeaders())
+	for i, row := range rows {
+		if i == 0 || rowCell(row, columns, "ID") != channelID {
+			continue
+		}
+		if err := file.RemoveRow(ChannelsSheet, i+1); err != nil {
+			return fmt.Errorf("failed to remove channel row: %w", err)
 		}
-		file.Close()
-	}()
+		if err := es.saveWithRetry(file); err != nil {
+			return err
+		}
+		es.logger.Debug("Removed channel from Excel", "channelID", channelID)
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", types.ErrChannelNotFound, channelID)
+}
+
+// SaveSummary saves a summary to Excel, rejecting IDs that are already in use
+func (es *ExcelStorage) SaveSummary(ctx context.Context, summary types.Summary) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
 
 	// Find the next empty row
 	rows, err := file.GetRows(SummariesSheet)
@@ -154,93 +455,129 @@ func (es *ExcelStorage) SaveSummary(ctx context.Context, summary types.Summary)
 		return fmt.Errorf("failed to get rows from summaries sheet: %w", err)
 	}
 
+	columns := summaryColumns(rows)
+	for i, row := range rows {
+		if i > 0 && rowCell(row, columns, "ID") == summary.ID {
+			return fmt.Errorf("%w: %s", types.ErrDuplicateSummaryID, summary.ID)
+		}
+	}
+
 	nextRow := len(rows) + 1
-	excelSummary := FromSummary(summary)
+	if err := writeSummaryRow(file, nextRow, columns, summary); err != nil {
+		return err
+	}
 
-	// Write summary data - all 12 columns
-	data := []interface{}{
-		excelSummary.ID,
-		excelSummary.VideoID,
-		excelSummary.VideoTitle,
-		excelSummary.ChannelName,
-		excelSummary.Summary,
-		excelSummary.CreatedAt,
-		excelSummary.Status,
-		excelSummary.VideoURL,
-		excelSummary.PublishedAt,
-		excelSummary.ThumbnailURL,
-		excelSummary.Duration,
-		excelSummary.ViewCount,
-	}
-
-	for i, value := range data {
-		cell := fmt.Sprintf("%c%d", 'A'+i, nextRow)
-		if err := file.SetCellValue(SummariesSheet, cell, value); err != nil {
-			return fmt.Errorf("failed to set cell %s: %w", cell, err)
+	// In latest mode, drop the oldest summaries beyond the limit. Pending ("New")
+	// summaries are never evicted, so the limit can be exceeded until they are sent.
+	if excess := nextRow - 1 - es.latestCount; es.latestCount > 0 && excess > 0 {
+		var evict []int
+		for i := 1; i < len(rows) && len(evict) < excess; i++ {
+			if rowCell(rows[i], columns, "Status") != "New" {
+				evict = append(evict, i+1)
+			}
+		}
+		// Remove from the bottom up so earlier row numbers stay valid
+		for j := len(evict) - 1; j >= 0; j-- {
+			if err := file.RemoveRow(SummariesSheet, evict[j]); err != nil {
+				return fmt.Errorf("failed to evict old summary: %w", err)
+			}
+		}
+		if len(evict) > 0 {
+			es.logger.Debug("Evicted old summaries", "count", len(evict), "limit", es.latestCount)
 		}
+		if len(evict) < excess {
+			es.logger.Debug("Keeping pending summaries beyond the latest count", "over", excess-len(evict), "limit", es.latestCount)
+		}
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return err
 	}
 
 	es.logger.Debug("Saved summary to Excel", "summaryID", summary.ID, "videoID", summary.VideoID)
 	return nil
 }
 
-// GetPendingSummaries retrieves summaries with "New" status
-func (es *ExcelStorage) GetPendingSummaries(ctx context.Context) ([]types.Summary, error) {
+// UpdateSummary overwrites the row of the summary with the same ID
+func (es *ExcelStorage) UpdateSummary(ctx context.Context, summary types.Summary) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
 	file, err := excelize.OpenFile(es.filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open Excel file: %w", err)
+		return fmt.Errorf("failed to open Excel file: %w", err)
 	}
 	defer file.Close()
 
 	rows, err := file.GetRows(SummariesSheet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get rows from summaries sheet: %w", err)
+		return fmt.Errorf("failed to get rows from summaries sheet: %w", err)
 	}
 
-	var summaries []types.Summary
-	// Skip header row (index 0)
-	for i := 1; i < len(rows); i++ {
-		row := rows[i]
-		if len(row) < 7 { // Minimum required columns
+	columns := summaryColumns(rows)
+	for i, row := range rows {
+		if i == 0 || rowCell(row, columns, "ID") != summary.ID {
 			continue
 		}
-
-		// Check if status is "New"
-		status := ""
-		if len(row) > 6 {
-			status = row[6]
+		if err := writeSummaryRow(file, i+1, columns, summary); err != nil {
+			return err
 		}
-		if status != "New" {
-			continue
+		if err := es.saveWithRetry(file); err != nil {
+			return err
 		}
+		es.logger.Debug("Updated summary in Excel", "summaryID", summary.ID, "videoID", summary.VideoID)
+		return nil
+	}
 
-		excelSummary := ExcelSummary{
-			ID:          row[0],
-			VideoID:     row[1],
-			VideoTitle:  row[2],
-			ChannelName: row[3],
-			Summary:     row[4],
-			CreatedAt:   row[5],
-			Status:      status,
-		}
+	return fmt.Errorf("summary not found: %s", summary.ID)
+}
 
-		// Read additional columns (VideoURL, PublishedAt, ThumbnailURL, Duration, ViewCount)
-		if len(row) > 7 {
-			excelSummary.VideoURL = row[7]
-		}
-		if len(row) > 8 {
-			excelSummary.PublishedAt = row[8]
+// writeSummaryRow writes every summary field to the given row, placing each under
+// its header so reordered or inserted columns are left alone
+func writeSummaryRow(file *excelize.File, rowNum int, columns map[string]int, summary types.Summary) error {
+	excelSummary := FromSummary(summary)
+	for header, value := range excelSummary.fields() {
+		i, ok := columns[header]
+		if !ok {
+			return fmt.Errorf("summaries sheet has no %s column", header)
 		}
-		if len(row) > 9 {
-			excelSummary.ThumbnailURL = row[9]
+		cell, err := excelize.CoordinatesToCellName(i+1, rowNum)
+		if err != nil {
+			return fmt.Errorf("failed to locate %s column: %w", header, err)
 		}
-		if len(row) > 10 {
-			excelSummary.Duration = row[10]
+		if err := file.SetCellValue(SummariesSheet, cell, *value); err != nil {
+			return fmt.Errorf("failed to set cell %s: %w", cell, err)
 		}
-		if len(row) > 11 {
-			excelSummary.ViewCount = row[11]
+	}
+	return nil
+}
+
+// GetPendingSummaries retrieves summaries with "New" status
+func (es *ExcelStorage) GetPendingSummaries(ctx context.Context) ([]types.Summary, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(SummariesSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from summaries sheet: %w", err)
+	}
+
+	columns := summaryColumns(rows)
+	var summaries []types.Summary
+	// Skip header row (index 0)
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		if rowCell(row, columns, "ID") == "" || rowCell(row, columns, "Status") != "New" {
+			continue
 		}
 
+		excelSummary := summaryFromRow(row, columns)
 		summary, err := excelSummary.ToSummary()
 		if err != nil {
 			es.logger.Warn("Failed to parse summary date", "error", err, "summaryID", excelSummary.ID)
@@ -254,130 +591,725 @@ func (es *ExcelStorage) GetPendingSummaries(ctx context.Context) ([]types.Summar
 	return summaries, nil
 }
 
-// MarkSummariesProcessed updates the status of summaries to "Processed"
-func (es *ExcelStorage) MarkSummariesProcessed(ctx context.Context, summaryIDs []string) error {
-	if len(summaryIDs) == 0 {
-		return nil
+// GetAllSummaries retrieves a page of summaries regardless of status, along with the total count.
+// A limit of zero or less returns every summary after offset.
+func (es *ExcelStorage) GetAllSummaries(ctx context.Context, offset, limit int) ([]types.Summary, int, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative")
 	}
 
-	file, err := excelize.OpenFile(es.filePath)
+	all, err := es.loadSummaries()
 	if err != nil {
-		return fmt.Errorf("failed to open Excel file: %w", err)
+		return nil, 0, err
 	}
-	defer func() {
-		if saveErr := file.SaveAs(es.filePath); saveErr != nil {
-			es.logger.Error("Failed to save Excel file", saveErr)
-		}
-		file.Close()
-	}()
 
-	rows, err := file.GetRows(SummariesSheet)
-	if err != nil {
-		return fmt.Errorf("failed to get rows from summaries sheet: %w", err)
+	total := len(all)
+	if offset >= total {
+		return nil, total, nil
 	}
 
-	// Create a map for faster lookup
-	idMap := make(map[string]bool)
-	for _, id := range summaryIDs {
-		idMap[id] = true
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
 	}
 
-	updatedCount := 0
-	// Skip header row (index 0)
-	for i := 1; i < len(rows); i++ {
-		row := rows[i]
-		if len(row) < 1 {
-			continue
-		}
+	es.logger.Debug("Retrieved summaries page", "offset", offset, "limit", limit, "count", end-offset, "total", total)
+	return all[offset:end], total, nil
+}
 
-		summaryID := row[0]
-		if idMap[summaryID] {
-			// Update status to "Processed"
-			statusCell := fmt.Sprintf("G%d", i+1) // Column G is status (0-based index 6)
-			if err := file.SetCellValue(SummariesSheet, statusCell, "Processed"); err != nil {
-				es.logger.Error("Failed to update summary status", err, "summaryID", summaryID)
-				continue
-			}
-			updatedCount++
+// GetSummariesByDateRange retrieves summaries created within [start, end) regardless of status
+func (es *ExcelStorage) GetSummariesByDateRange(ctx context.Context, start, end time.Time) ([]types.Summary, error) {
+	all, err := es.loadSummaries()
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []types.Summary
+	for _, summary := range all {
+		if !summary.CreatedAt.Before(start) && summary.CreatedAt.Before(end) {
+			summaries = append(summaries, summary)
 		}
 	}
 
-	es.logger.Debug("Marked summaries as processed", "count", updatedCount)
-	return nil
+	es.logger.Debug("Retrieved summaries by date range", "start", start, "end", end, "count", len(summaries))
+	return summaries, nil
 }
 
-// IsVideoProcessed checks if a video has already been processed
-func (es *ExcelStorage) IsVideoProcessed(ctx context.Context, videoID string) (bool, error) {
+// GetSummaryChannels returns the unique channel names that have summaries, sorted
+func (es *ExcelStorage) GetSummaryChannels(ctx context.Context) ([]string, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
 	file, err := excelize.OpenFile(es.filePath)
 	if err != nil {
-		return false, fmt.Errorf("failed to open Excel file: %w", err)
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
 	}
 	defer file.Close()
 
-	rows, err := file.GetRows(ProcessedVideosSheet)
+	rows, err := file.GetRows(SummariesSheet)
 	if err != nil {
-		return false, fmt.Errorf("failed to get rows from processed videos sheet: %w", err)
+		return nil, fmt.Errorf("failed to get rows from summaries sheet: %w", err)
 	}
 
+	columns := summaryColumns(rows)
+	seen := make(map[string]bool)
+	var channels []string
 	// Skip header row (index 0)
 	for i := 1; i < len(rows); i++ {
-		row := rows[i]
-		if len(row) < 1 {
+		name := strings.TrimSpace(rowCell(rows[i], columns, "ChannelName"))
+		if name == "" || seen[name] {
 			continue
 		}
-		if row[0] == videoID {
-			return true, nil
-		}
+		seen[name] = true
+		channels = append(channels, name)
 	}
+	sort.Strings(channels)
 
-	return false, nil
+	es.logger.Debug("Retrieved summary channels", "count", len(channels))
+	return channels, nil
 }
 
-// MarkVideoProcessed adds a video to the processed videos list
-func (es *ExcelStorage) MarkVideoProcessed(ctx context.Context, videoID string) error {
-	// First check if already processed
-	processed, err := es.IsVideoProcessed(ctx, videoID)
-	if err != nil {
-		return err
-	}
-	if processed {
-		return nil // Already processed
-	}
+// loadSummaries reads every valid summary row from the Summaries sheet
+func (es *ExcelStorage) loadSummaries() ([]types.Summary, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
 
 	file, err := excelize.OpenFile(es.filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open Excel file: %w", err)
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
 	}
-	defer func() {
-		if saveErr := file.SaveAs(es.filePath); saveErr != nil {
-			es.logger.Error("Failed to save Excel file", saveErr)
-		}
-		file.Close()
-	}()
+	defer file.Close()
 
-	// Find the next empty row
-	rows, err := file.GetRows(ProcessedVideosSheet)
+	rows, err := file.GetRows(SummariesSheet)
 	if err != nil {
-		return fmt.Errorf("failed to get rows from processed videos sheet: %w", err)
+		return nil, fmt.Errorf("failed to get rows from summaries sheet: %w", err)
 	}
 
-	nextRow := len(rows) + 1
-
-	// Write processed video data
-	data := []interface{}{
-		videoID,
-		"", // ChannelID - will be populated when we have video details
-		"", // Title - will be populated when we have video details
-		time.Now().Format("2006-01-02 15:04:05"),
-	}
+	columns := summaryColumns(rows)
+	var summaries []types.Summary
+	// Skip header row (index 0)
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		// ID and CreatedAt are required
+		if rowCell(row, columns, "ID") == "" || rowCell(row, columns, "CreatedAt") == "" {
+			continue
+		}
 
-	for i, value := range data {
-		cell := fmt.Sprintf("%c%d", 'A'+i, nextRow)
-		if err := file.SetCellValue(ProcessedVideosSheet, cell, value); err != nil {
-			return fmt.Errorf("failed to set cell %s: %w", cell, err)
+		excelSummary := summaryFromRow(row, columns)
+		summary, err := excelSummary.ToSummary()
+		if err != nil {
+			es.logger.Warn("Failed to parse summary date", "error", err, "summaryID", excelSummary.ID)
+			continue
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// summaryFromRow maps a Summaries sheet row onto an ExcelSummary by header name,
+// leaving fields empty for columns the sheet lacks or the row doesn't reach
+func summaryFromRow(row []string, columns map[string]int) ExcelSummary {
+	var excelSummary ExcelSummary
+	for header, value := range excelSummary.fields() {
+		*value = rowCell(row, columns, header)
+	}
+	return excelSummary
+}
+
+// MarkSummariesProcessed updates the status of summaries to "Processed"
+func (es *ExcelStorage) MarkSummariesProcessed(ctx context.Context, summaryIDs []string) error {
+	return es.UpdateSummaryStatus(ctx, summaryIDs, "Processed")
+}
+
+// UpdateSummaryStatus sets the status of the given summaries
+func (es *ExcelStorage) UpdateSummaryStatus(ctx context.Context, summaryIDs []string, status string) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if len(summaryIDs) == 0 {
+		return nil
+	}
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(SummariesSheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from summaries sheet: %w", err)
+	}
+
+	// Create a map for faster lookup
+	idMap := make(map[string]bool)
+	for _, id := range summaryIDs {
+		idMap[id] = true
+	}
+
+	columns := summaryColumns(rows)
+	statusColumn, ok := columns["Status"]
+	if !ok {
+		return fmt.Errorf("summaries sheet has no Status column")
+	}
+
+	updatedCount := 0
+	// Skip header row (index 0)
+	for i := 1; i < len(rows); i++ {
+		summaryID := rowCell(rows[i], columns, "ID")
+		if idMap[summaryID] {
+			statusCell, err := excelize.CoordinatesToCellName(statusColumn+1, i+1)
+			if err != nil {
+				return fmt.Errorf("failed to locate Status column: %w", err)
+			}
+			if err := file.SetCellValue(SummariesSheet, statusCell, status); err != nil {
+				es.logger.Error("Failed to update summary status", err, "summaryID", summaryID)
+				continue
+			}
+			updatedCount++
+		}
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return err
+	}
+
+	es.logger.Debug("Updated summary status", "status", status, "count", updatedCount)
+	return nil
+}
+
+// IsVideoProcessed checks if a video has already been processed
+func (es *ExcelStorage) IsVideoProcessed(ctx context.Context, videoID string) (bool, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	es.processedMu.Lock()
+	defer es.processedMu.Unlock()
+
+	if err := es.loadProcessedIDs(); err != nil {
+		return false, err
+	}
+	return es.processedIDs[videoID], nil
+}
+
+// loadProcessedIDs reads the processed video IDs into the cache if not already loaded.
+// The caller must hold processedMu.
+func (es *ExcelStorage) loadProcessedIDs() error {
+	if es.processedIDs != nil {
+		return nil
+	}
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(ProcessedVideosSheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from processed videos sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, ProcessedVideoHeaders())
+	processedIDs := make(map[string]bool, len(rows))
+	// Skip header row (index 0)
+	for i := 1; i < len(rows); i++ {
+		if videoID := rowCell(rows[i], columns, "VideoID"); videoID != "" {
+			processedIDs[videoID] = true
+		}
+	}
+
+	es.processedIDs = processedIDs
+	es.logger.Debug("Loaded processed videos", "count", len(processedIDs))
+	return nil
+}
+
+// GetChannelsFirstProcessed returns when each channel was first processed, keyed by channel ID.
+// Channels that were never processed are absent.
+func (es *ExcelStorage) GetChannelsFirstProcessed(ctx context.Context) (map[string]time.Time, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(ChannelHistorySheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from channel history sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, ChannelHistoryHeaders())
+	firstProcessed := make(map[string]time.Time)
+	// Skip header row (index 0)
+	for i := 1; i < len(rows); i++ {
+		channelID := rowCell(rows[i], columns, "ChannelID")
+		if channelID == "" {
+			continue
+		}
+		at, err := time.ParseInLocation("2006-01-02 15:04:05", rowCell(rows[i], columns, "FirstProcessedAt"), time.Local)
+		if err != nil {
+			es.logger.Warn("Failed to parse channel first processed time", "row", i+1, "error", err)
+			continue
+		}
+		firstProcessed[channelID] = at
+	}
+
+	return firstProcessed, nil
+}
+
+// MarkChannelFirstProcessed records when a channel was first processed; an existing record is kept
+func (es *ExcelStorage) MarkChannelFirstProcessed(ctx context.Context, channelID string, at time.Time) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(ChannelHistorySheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from channel history sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, ChannelHistoryHeaders())
+	for i, row := range rows {
+		if i > 0 && rowCell(row, columns, "ChannelID") == channelID {
+			return nil
+		}
+	}
+
+	if err := writeRow(file, ChannelHistorySheet, len(rows)+1, columns, map[string]interface{}{
+		"ChannelID":        channelID,
+		"FirstProcessedAt": at.Format("2006-01-02 15:04:05"),
+	}); err != nil {
+		return err
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return err
+	}
+
+	es.logger.Debug("Recorded channel first processed", "channelID", channelID)
+	return nil
+}
+
+// GetChannelActivity returns each tracked channel's last upload and last check, keyed by channel ID
+func (es *ExcelStorage) GetChannelActivity(ctx context.Context) (map[string]types.ChannelActivity, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(ChannelActivitySheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from channel activity sheet: %w", err)
+	}
+
+	// Unset or unreadable times stay zero, which only makes a channel look active
+	parse := func(value string) time.Time {
+		at, _ := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+		return at
+	}
+
+	columns := sheetColumns(rows, ChannelActivityHeaders())
+	activity := make(map[string]types.ChannelActivity)
+	// Skip header row (index 0)
+	for i := 1; i < len(rows); i++ {
+		channelID := rowCell(rows[i], columns, "ChannelID")
+		if channelID == "" {
+			continue
+		}
+		activity[channelID] = types.ChannelActivity{
+			LastUploadAt:  parse(rowCell(rows[i], columns, "LastUploadAt")),
+			LastCheckedAt: parse(rowCell(rows[i], columns, "LastCheckedAt")),
+		}
+	}
+
+	return activity, nil
+}
+
+// SetChannelActivity records a channel's last upload and last check, replacing any previous record
+func (es *ExcelStorage) SetChannelActivity(ctx context.Context, channelID string, activity types.ChannelActivity) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(ChannelActivitySheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from channel activity sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, ChannelActivityHeaders())
+	rowNum := len(rows) + 1
+	for i, row := range rows {
+		if i > 0 && rowCell(row, columns, "ChannelID") == channelID {
+			rowNum = i + 1
+			break
+		}
+	}
+
+	lastUpload := ""
+	if !activity.LastUploadAt.IsZero() {
+		lastUpload = activity.LastUploadAt.Local().Format("2006-01-02 15:04:05")
+	}
+	if err := writeRow(file, ChannelActivitySheet, rowNum, columns, map[string]interface{}{
+		"ChannelID":     channelID,
+		"LastUploadAt":  lastUpload,
+		"LastCheckedAt": activity.LastCheckedAt.Local().Format("2006-01-02 15:04:05"),
+	}); err != nil {
+		return err
+	}
+
+	return es.saveWithRetry(file)
+}
+
+// RecordVideoFailure stores a failed video in the FailedVideos sheet, updating the
+// row of an earlier failure of the same video and counting the attempt
+func (es *ExcelStorage) RecordVideoFailure(ctx context.Context, failure types.VideoFailure) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(FailedVideosSheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from failed videos sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, FailedVideoHeaders())
+	rowNum := len(rows) + 1
+	attempts := 1
+	for i, row := range rows {
+		if i > 0 && rowCell(row, columns, "VideoID") == failure.VideoID {
+			rowNum = i + 1
+			previous, _ := strconv.Atoi(rowCell(row, columns, "Attempts"))
+			attempts = previous + 1
+			break
 		}
 	}
 
-	es.logger.Debug("Marked video as processed", "videoID", videoID)
+	if err := writeRow(file, FailedVideosSheet, rowNum, columns, map[string]interface{}{
+		"VideoID":   failure.VideoID,
+		"ChannelID": failure.ChannelID,
+		"Channel":   failure.Channel,
+		"Title":     failure.Title,
+		"Error":     failure.Error,
+		"FailedAt":  failure.FailedAt.Local().Format("2006-01-02 15:04:05"),
+		"Attempts":  attempts,
+	}); err != nil {
+		return err
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return err
+	}
+
+	es.logger.Debug("Recorded failed video", "videoID", failure.VideoID, "attempts", attempts)
 	return nil
 }
+
+// GetVideoFailures returns the failed videos recorded in the FailedVideos sheet
+func (es *ExcelStorage) GetVideoFailures(ctx context.Context) ([]types.VideoFailure, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(FailedVideosSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from failed videos sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, FailedVideoHeaders())
+	var failures []types.VideoFailure
+	// Skip header row (index 0)
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		videoID := rowCell(row, columns, "VideoID")
+		if videoID == "" {
+			continue
+		}
+
+		failedAt, _ := time.ParseInLocation("2006-01-02 15:04:05", rowCell(row, columns, "FailedAt"), time.Local)
+		attempts, _ := strconv.Atoi(rowCell(row, columns, "Attempts"))
+		failures = append(failures, types.VideoFailure{
+			VideoID:   videoID,
+			ChannelID: rowCell(row, columns, "ChannelID"),
+			Channel:   rowCell(row, columns, "Channel"),
+			Title:     rowCell(row, columns, "Title"),
+			Error:     rowCell(row, columns, "Error"),
+			FailedAt:  failedAt,
+			Attempts:  attempts,
+		})
+	}
+
+	return failures, nil
+}
+
+// GetLastDigestSent returns when the last digest was sent, or the zero time if none was recorded
+func (es *ExcelStorage) GetLastDigestSent(ctx context.Context) (time.Time, error) {
+	value, err := es.getState(lastDigestSentKey)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+
+	sentAt, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse last digest time %q: %w", value, err)
+	}
+	return sentAt, nil
+}
+
+// SetLastDigestSent records when a digest was sent
+func (es *ExcelStorage) SetLastDigestSent(ctx context.Context, at time.Time) error {
+	return es.setState(lastDigestSentKey, at.Format("2006-01-02 15:04:05"))
+}
+
+// GetLastSuccessfulRun returns when the last successful run started, or the zero time if none was recorded
+func (es *ExcelStorage) GetLastSuccessfulRun(ctx context.Context) (time.Time, error) {
+	value, err := es.getState(lastSuccessfulRunKey)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+
+	startedAt, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse last successful run time %q: %w", value, err)
+	}
+	return startedAt, nil
+}
+
+// SetLastSuccessfulRun records when a successful run started
+func (es *ExcelStorage) SetLastSuccessfulRun(ctx context.Context, at time.Time) error {
+	return es.setState(lastSuccessfulRunKey, at.Format("2006-01-02 15:04:05"))
+}
+
+// getState returns the value stored under key in the state sheet ("" if absent)
+func (es *ExcelStorage) getState(key string) (string, error) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(StateSheet)
+	if err != nil {
+		return "", fmt.Errorf("failed to get rows from state sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, StateHeaders())
+	for i, row := range rows {
+		if i > 0 && rowCell(row, columns, "Key") == key {
+			return rowCell(row, columns, "Value"), nil
+		}
+	}
+	return "", nil
+}
+
+// setState stores value under key in the state sheet, replacing any previous value
+func (es *ExcelStorage) setState(key, value string) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(StateSheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from state sheet: %w", err)
+	}
+
+	columns := sheetColumns(rows, StateHeaders())
+	rowNum := len(rows) + 1
+	for i, row := range rows {
+		if i > 0 && rowCell(row, columns, "Key") == key {
+			rowNum = i + 1
+			break
+		}
+	}
+
+	if err := writeRow(file, StateSheet, rowNum, columns, map[string]interface{}{
+		"Key":   key,
+		"Value": value,
+	}); err != nil {
+		return err
+	}
+
+	return es.saveWithRetry(file)
+}
+
+// MarkVideoProcessed adds a video to the processed videos list
+func (es *ExcelStorage) MarkVideoProcessed(ctx context.Context, video types.Video) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	es.processedMu.Lock()
+	defer es.processedMu.Unlock()
+
+	// First check if already processed
+	if err := es.loadProcessedIDs(); err != nil {
+		return err
+	}
+	if es.processedIDs[video.ID] {
+		return nil // Already processed
+	}
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	// Find the next empty row
+	rows, err := file.GetRows(ProcessedVideosSheet)
+	if err != nil {
+		return fmt.Errorf("failed to get rows from processed videos sheet: %w", err)
+	}
+
+	// Write processed video data
+	if err := writeRow(file, ProcessedVideosSheet, len(rows)+1, sheetColumns(rows, ProcessedVideoHeaders()), map[string]interface{}{
+		"VideoID":     video.ID,
+		"ChannelID":   video.ChannelID,
+		"Title":       video.Title,
+		"ProcessedAt": time.Now().Format("2006-01-02 15:04:05"),
+	}); err != nil {
+		return err
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return err
+	}
+
+	es.processedIDs[video.ID] = true
+	es.logger.Debug("Marked video as processed", "videoID", video.ID)
+	return nil
+}
+
+// ClearProcessedVideos removes processed-video rows for a channel (empty channelID = all).
+// Rows recorded before the channel ID was stored are matched through their summary's channel name.
+func (es *ExcelStorage) ClearProcessedVideos(ctx context.Context, channelID string) (int, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	es.processedMu.Lock()
+	defer es.processedMu.Unlock()
+
+	// Reload the cache on next use rather than tracking which rows were removed
+	es.processedIDs = nil
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(ProcessedVideosSheet)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows from processed videos sheet: %w", err)
+	}
+
+	var legacyVideoIDs map[string]bool
+	if channelID != "" {
+		legacyVideoIDs, err = channelVideoIDs(file, channelID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Remove from the bottom up so earlier row numbers stay valid; skip the header row
+	columns := sheetColumns(rows, ProcessedVideoHeaders())
+	cleared := 0
+	for i := len(rows) - 1; i >= 1; i-- {
+		videoID := rowCell(rows[i], columns, "VideoID")
+		if videoID == "" {
+			continue
+		}
+
+		if channelID != "" {
+			rowChannelID := rowCell(rows[i], columns, "ChannelID")
+			if rowChannelID != channelID && !(rowChannelID == "" && legacyVideoIDs[videoID]) {
+				continue
+			}
+		}
+
+		if err := file.RemoveRow(ProcessedVideosSheet, i+1); err != nil {
+			return 0, fmt.Errorf("failed to remove processed video row %d: %w", i+1, err)
+		}
+		cleared++
+	}
+
+	if cleared == 0 {
+		return 0, nil
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return 0, err
+	}
+
+	es.logger.Info("Cleared processed videos", "channelID", channelID, "count", cleared)
+	return cleared, nil
+}
+
+// channelVideoIDs returns the IDs of videos summarized for a channel, matched by channel name
+func channelVideoIDs(file *excelize.File, channelID string) (map[string]bool, error) {
+	channelRows, err := file.GetRows(ChannelsSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from channels sheet: %w", err)
+	}
+
+	channelName := ""
+	for i := 1; i < len(channelRows); i++ {
+		if len(channelRows[i]) >= 2 && channelRows[i][0] == channelID {
+			channelName = channelRows[i][1]
+			break
+		}
+	}
+	if channelName == "" {
+		return nil, nil
+	}
+
+	summaryRows, err := file.GetRows(SummariesSheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from summaries sheet: %w", err)
+	}
+
+	columns := summaryColumns(summaryRows)
+	videoIDs := make(map[string]bool)
+	for i := 1; i < len(summaryRows); i++ {
+		summary := summaryFromRow(summaryRows[i], columns)
+		if summary.ChannelName == channelName {
+			videoIDs[summary.VideoID] = true
+		}
+	}
+	return videoIDs, nil
+}
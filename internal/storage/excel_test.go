@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// nopLogger discards all log output
+type nopLogger struct{}
+
+func (nopLogger) Info(msg string, fields ...interface{})             {}
+func (nopLogger) Error(msg string, err error, fields ...interface{}) {}
+func (nopLogger) Debug(msg string, fields ...interface{})            {}
+func (nopLogger) Warn(msg string, fields ...interface{})             {}
+
+// newTestExcelStorage returns an initialized Excel storage in a temporary directory
+func newTestExcelStorage(t *testing.T) *ExcelStorage {
+	t.Helper()
+	es := NewExcelStorage(filepath.Join(t.TempDir(), "data.xlsx"), nopLogger{})
+	if err := es.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	return es
+}
+
+func TestInitializeCreatesExpectedSheets(t *testing.T) {
+	es := newTestExcelStorage(t)
+
+	// Initializing an existing file must not add or duplicate anything
+	if err := es.Initialize(); err != nil {
+		t.Fatalf("second Initialize() error = %v", err)
+	}
+
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	wantHeaders := map[string][]string{
+		ChannelsSheet:        ChannelHeaders(),
+		ProcessedVideosSheet: ProcessedVideoHeaders(),
+		SummariesSheet:       SummaryHeaders(),
+		ChannelHistorySheet:  ChannelHistoryHeaders(),
+		ChannelActivitySheet: ChannelActivityHeaders(),
+		StateSheet:           StateHeaders(),
+		FailedVideosSheet:    FailedVideoHeaders(),
+	}
+
+	var want []string
+	for sheet := range wantHeaders {
+		want = append(want, sheet)
+	}
+	got := file.GetSheetList()
+	sort.Strings(want)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("sheets = %v, want %v", got, want)
+	}
+
+	for sheet, headers := range wantHeaders {
+		rows, err := file.GetRows(sheet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 1 || !reflect.DeepEqual(rows[0], headers) {
+			t.Errorf("sheet %s rows = %v, want only the header row %v", sheet, rows, headers)
+		}
+	}
+
+	if active := file.GetSheetName(file.GetActiveSheetIndex()); active != ChannelsSheet {
+		t.Errorf("active sheet = %s, want %s", active, ChannelsSheet)
+	}
+}
+
+func TestWritesFollowMovedColumns(t *testing.T) {
+	ctx := context.Background()
+	es := newTestExcelStorage(t)
+
+	// A user inserts a notes column in front of every sheet's data
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sheet := range []string{ProcessedVideosSheet, FailedVideosSheet, ChannelActivitySheet, StateSheet, ChannelHistorySheet} {
+		if err := file.InsertCols(sheet, "A", 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := file.SetCellValue(sheet, "A1", "Notes"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := file.SaveAs(es.filePath); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	at := time.Date(2025, time.March, 4, 10, 0, 0, 0, time.Local)
+	if err := es.MarkVideoProcessed(ctx, types.Video{ID: "vid1", ChannelID: "UCa", Title: "Video"}); err != nil {
+		t.Fatal(err)
+	}
+	failure := types.VideoFailure{VideoID: "vid2", ChannelID: "UCa", Channel: "A", Title: "Broken", Error: "boom", FailedAt: at}
+	for i := 0; i < 2; i++ {
+		if err := es.RecordVideoFailure(ctx, failure); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := es.SetChannelActivity(ctx, "UCa", types.ChannelActivity{LastUploadAt: at, LastCheckedAt: at}); err != nil {
+		t.Fatal(err)
+	}
+	if err := es.SetLastDigestSent(ctx, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := es.MarkChannelFirstProcessed(ctx, "UCa", at); err != nil {
+		t.Fatal(err)
+	}
+
+	es.processedIDs = nil
+	if processed, err := es.IsVideoProcessed(ctx, "vid1"); err != nil || !processed {
+		t.Errorf("IsVideoProcessed() = %v, %v, want true", processed, err)
+	}
+	failures, err := es.GetVideoFailures(ctx)
+	if err != nil || len(failures) != 1 || failures[0].Attempts != 2 || failures[0].Error != "boom" {
+		t.Errorf("GetVideoFailures() = %+v, %v, want one failure with 2 attempts", failures, err)
+	}
+	activity, err := es.GetChannelActivity(ctx)
+	if err != nil || !activity["UCa"].LastUploadAt.Equal(at) {
+		t.Errorf("GetChannelActivity() = %+v, %v", activity, err)
+	}
+	if sentAt, err := es.GetLastDigestSent(ctx); err != nil || !sentAt.Equal(at) {
+		t.Errorf("GetLastDigestSent() = %v, %v, want %v", sentAt, err, at)
+	}
+	if first, err := es.GetChannelsFirstProcessed(ctx); err != nil || first["UCa"].IsZero() {
+		t.Errorf("GetChannelsFirstProcessed() = %v, %v", first, err)
+	}
+
+	// The inserted column stays empty
+	file, err = excelize.OpenFile(es.filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for _, sheet := range []string{ProcessedVideosSheet, FailedVideosSheet, ChannelActivitySheet, StateSheet, ChannelHistorySheet} {
+		if value, _ := file.GetCellValue(sheet, "A2"); value != "" {
+			t.Errorf("%s sheet wrote %q into the inserted column", sheet, value)
+		}
+	}
+}
+
+func TestLatestModeKeepsPendingSummaries(t *testing.T) {
+	ctx := context.Background()
+	es := newTestExcelStorage(t)
+	es.SetLatestCount(2)
+
+	save := func(id, status string) {
+		t.Helper()
+		if err := es.SaveSummary(ctx, types.Summary{ID: id, VideoID: id, Status: status}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save("pending1", "New")
+	save("sent1", "Processed")
+	save("sent2", "Processed")
+	save("pending2", "New")
+
+	summaries, _, err := es.GetAllSummaries(ctx, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, summary := range summaries {
+		ids = append(ids, summary.ID)
+	}
+	sort.Strings(ids)
+	if want := []string{"pending1", "pending2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("kept summaries %v, want %v", ids, want)
+	}
+
+	// With nothing left to evict the pending ones stay beyond the limit
+	save("pending3", "New")
+	pending, err := es.GetPendingSummaries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 3 {
+		t.Errorf("got %d pending summaries, want 3", len(pending))
+	}
+}
+
+func TestInitializeBackfillsChannelHistory(t *testing.T) {
+	ctx := context.Background()
+	es := newTestExcelStorage(t)
+	for _, video := range []types.Video{{ID: "vid1", ChannelID: "UCa"}, {ID: "vid2", ChannelID: "UCb"}, {ID: "vid3", ChannelID: "UCa"}} {
+		if err := es.MarkVideoProcessed(ctx, video); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A workbook from before the channel history sheet existed
+	file, err := excelize.OpenFile(es.filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.DeleteSheet(ChannelHistorySheet); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SaveAs(es.filePath); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	if err := es.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	first, err := es.GetChannelsFirstProcessed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 2 || first["UCa"].IsZero() || first["UCb"].IsZero() {
+		t.Errorf("GetChannelsFirstProcessed() = %v, want both channels with processed videos", first)
+	}
+}
+
+func TestChannelFirstProcessedKeepsLocalTime(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC+5", 5*60*60)
+	defer func() { time.Local = local }()
+
+	ctx := context.Background()
+	es := newTestExcelStorage(t)
+	at := time.Date(2025, time.March, 4, 10, 0, 0, 0, time.Local)
+	if err := es.MarkChannelFirstProcessed(ctx, "UCa", at); err != nil {
+		t.Fatal(err)
+	}
+	first, err := es.GetChannelsFirstProcessed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first["UCa"].Equal(at) {
+		t.Errorf("first processed = %v, want %v", first["UCa"], at)
+	}
+}
@@ -0,0 +1,333 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// MemoryStorage implements the types.Storage interface in memory.
+// It is intended for tests and ephemeral runs; nothing is persisted.
+type MemoryStorage struct {
+	mu              sync.RWMutex
+	channels        []types.Channel
+	summaries       []types.Summary
+	processedVideos map[string]processedVideo
+	firstProcessed  map[string]time.Time
+	channelActivity map[string]types.ChannelActivity
+	lastDigestSent  time.Time
+	lastSuccessful  time.Time
+	failures        []types.VideoFailure
+}
+
+// processedVideo records when a video was processed and which channel it came from
+type processedVideo struct {
+	channelID   string
+	processedAt time.Time
+}
+
+// NewMemoryStorage creates an empty in-memory storage, optionally seeded with channels
+func NewMemoryStorage(channels ...types.Channel) *MemoryStorage {
+	return &MemoryStorage{
+		channels:        append([]types.Channel(nil), channels...),
+		processedVideos: make(map[string]processedVideo),
+		firstProcessed:  make(map[string]time.Time),
+		channelActivity: make(map[string]types.ChannelActivity),
+	}
+}
+
+// GetChannels returns the configured channels
+func (ms *MemoryStorage) GetChannels(ctx context.Context) ([]types.Channel, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return append([]types.Channel(nil), ms.channels...), nil
+}
+
+// AddChannel appends a channel, rejecting IDs that are already listed
+func (ms *MemoryStorage) AddChannel(ctx context.Context, channel types.Channel) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if channel.ID == "" || channel.Name == "" {
+		return fmt.Errorf("channel ID and name are required")
+	}
+	for _, existing := range ms.channels {
+		if existing.ID == channel.ID {
+			return fmt.Errorf("%w: %s", types.ErrDuplicateChannel, channel.ID)
+		}
+	}
+
+	ms.channels = append(ms.channels, channel)
+	return nil
+}
+
+// RemoveChannel deletes the channel with the given ID
+func (ms *MemoryStorage) RemoveChannel(ctx context.Context, channelID string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for i, existing := range ms.channels {
+		if existing.ID == channelID {
+			ms.channels = append(ms.channels[:i], ms.channels[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", types.ErrChannelNotFound, channelID)
+}
+
+// SaveSummary stores a summary, rejecting IDs that are already in use
+func (ms *MemoryStorage) SaveSummary(ctx context.Context, summary types.Summary) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for _, existing := range ms.summaries {
+		if existing.ID == summary.ID {
+			return fmt.Errorf("%w: %s", types.ErrDuplicateSummaryID, summary.ID)
+		}
+	}
+
+	ms.summaries = append(ms.summaries, summary)
+	return nil
+}
+
+// GetPendingSummaries returns summaries with "New" status
+func (ms *MemoryStorage) GetPendingSummaries(ctx context.Context) ([]types.Summary, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var summaries []types.Summary
+	for _, summary := range ms.summaries {
+		if summary.Status == "New" {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// GetAllSummaries returns a page of summaries regardless of status, along with the total count
+func (ms *MemoryStorage) GetAllSummaries(ctx context.Context, offset, limit int) ([]types.Summary, int, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative")
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	total := len(ms.summaries)
+	if offset >= total {
+		return nil, total, nil
+	}
+
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+
+	return append([]types.Summary(nil), ms.summaries[offset:end]...), total, nil
+}
+
+// GetSummariesByDateRange returns summaries created within [start, end) regardless of status
+func (ms *MemoryStorage) GetSummariesByDateRange(ctx context.Context, start, end time.Time) ([]types.Summary, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var summaries []types.Summary
+	for _, summary := range ms.summaries {
+		if !summary.CreatedAt.Before(start) && summary.CreatedAt.Before(end) {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// GetSummaryChannels returns the unique channel names that have summaries, sorted
+func (ms *MemoryStorage) GetSummaryChannels(ctx context.Context) ([]string, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var channels []string
+	for _, summary := range ms.summaries {
+		if summary.ChannelName == "" || seen[summary.ChannelName] {
+			continue
+		}
+		seen[summary.ChannelName] = true
+		channels = append(channels, summary.ChannelName)
+	}
+	sort.Strings(channels)
+	return channels, nil
+}
+
+// MarkSummariesProcessed updates the status of summaries to "Processed"
+func (ms *MemoryStorage) MarkSummariesProcessed(ctx context.Context, summaryIDs []string) error {
+	return ms.UpdateSummaryStatus(ctx, summaryIDs, "Processed")
+}
+
+// UpdateSummaryStatus sets the status of the given summaries
+func (ms *MemoryStorage) UpdateSummaryStatus(ctx context.Context, summaryIDs []string, status string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	idMap := make(map[string]bool)
+	for _, id := range summaryIDs {
+		idMap[id] = true
+	}
+
+	for i := range ms.summaries {
+		if idMap[ms.summaries[i].ID] {
+			ms.summaries[i].Status = status
+		}
+	}
+	return nil
+}
+
+// UpdateSummary replaces the stored summary with the same ID
+func (ms *MemoryStorage) UpdateSummary(ctx context.Context, summary types.Summary) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for i := range ms.summaries {
+		if ms.summaries[i].ID == summary.ID {
+			ms.summaries[i] = summary
+			return nil
+		}
+	}
+	return fmt.Errorf("summary not found: %s", summary.ID)
+}
+
+// IsVideoProcessed checks if a video has already been processed
+func (ms *MemoryStorage) IsVideoProcessed(ctx context.Context, videoID string) (bool, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	_, ok := ms.processedVideos[videoID]
+	return ok, nil
+}
+
+// MarkVideoProcessed adds a video to the processed videos set
+func (ms *MemoryStorage) MarkVideoProcessed(ctx context.Context, video types.Video) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.processedVideos[video.ID]; !ok {
+		ms.processedVideos[video.ID] = processedVideo{channelID: video.ChannelID, processedAt: time.Now()}
+	}
+	return nil
+}
+
+// ClearProcessedVideos removes processed videos for a channel (empty channelID = all)
+func (ms *MemoryStorage) ClearProcessedVideos(ctx context.Context, channelID string) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	cleared := 0
+	for videoID, processed := range ms.processedVideos {
+		if channelID == "" || processed.channelID == channelID {
+			delete(ms.processedVideos, videoID)
+			cleared++
+		}
+	}
+	return cleared, nil
+}
+
+// GetChannelsFirstProcessed returns when each channel was first processed, keyed by channel ID
+func (ms *MemoryStorage) GetChannelsFirstProcessed(ctx context.Context) (map[string]time.Time, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	firstProcessed := make(map[string]time.Time, len(ms.firstProcessed))
+	for channelID, at := range ms.firstProcessed {
+		firstProcessed[channelID] = at
+	}
+	return firstProcessed, nil
+}
+
+// MarkChannelFirstProcessed records when a channel was first processed; an existing record is kept
+func (ms *MemoryStorage) MarkChannelFirstProcessed(ctx context.Context, channelID string, at time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.firstProcessed[channelID]; !ok {
+		ms.firstProcessed[channelID] = at
+	}
+	return nil
+}
+
+// GetChannelActivity returns each tracked channel's last upload and last check, keyed by channel ID
+func (ms *MemoryStorage) GetChannelActivity(ctx context.Context) (map[string]types.ChannelActivity, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	activity := make(map[string]types.ChannelActivity, len(ms.channelActivity))
+	for channelID, a := range ms.channelActivity {
+		activity[channelID] = a
+	}
+	return activity, nil
+}
+
+// SetChannelActivity records a channel's last upload and last check
+func (ms *MemoryStorage) SetChannelActivity(ctx context.Context, channelID string, activity types.ChannelActivity) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.channelActivity[channelID] = activity
+	return nil
+}
+
+// GetLastSuccessfulRun returns when the last successful run started (zero if never)
+func (ms *MemoryStorage) GetLastSuccessfulRun(ctx context.Context) (time.Time, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.lastSuccessful, nil
+}
+
+// SetLastSuccessfulRun records when a successful run started
+func (ms *MemoryStorage) SetLastSuccessfulRun(ctx context.Context, at time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.lastSuccessful = at
+	return nil
+}
+
+// RecordVideoFailure stores a failed video, replacing an earlier failure of the same video
+func (ms *MemoryStorage) RecordVideoFailure(ctx context.Context, failure types.VideoFailure) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for i, existing := range ms.failures {
+		if existing.VideoID == failure.VideoID {
+			failure.Attempts = existing.Attempts + 1
+			ms.failures[i] = failure
+			return nil
+		}
+	}
+	failure.Attempts = 1
+	ms.failures = append(ms.failures, failure)
+	return nil
+}
+
+// GetVideoFailures returns the recorded failed videos
+func (ms *MemoryStorage) GetVideoFailures(ctx context.Context) ([]types.VideoFailure, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return append([]types.VideoFailure(nil), ms.failures...), nil
+}
+
+// GetLastDigestSent returns when the last digest was sent (zero if never)
+func (ms *MemoryStorage) GetLastDigestSent(ctx context.Context) (time.Time, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.lastDigestSent, nil
+}
+
+// SetLastDigestSent records when a digest was sent
+func (ms *MemoryStorage) SetLastDigestSent(ctx context.Context, at time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.lastDigestSent = at
+	return nil
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"strconv"
+	"strings"
 	"time"
 	"youtube-summarizer/pkg/types"
 )
@@ -11,6 +12,14 @@ const (
 	ChannelsSheet        = "Channels"
 	ProcessedVideosSheet = "ProcessedVideos"
 	SummariesSheet       = "Summaries"
+	ChannelHistorySheet  = "ChannelHistory"
+	ChannelActivitySheet = "ChannelActivity"
+	StateSheet           = "State"
+	FailedVideosSheet    = "FailedVideos"
+
+	// State keys
+	lastDigestSentKey    = "LastDigestSent"
+	lastSuccessfulRunKey = "LastSuccessfulRun"
 )
 
 // ExcelChannel represents a channel record in Excel
@@ -19,6 +28,7 @@ type ExcelChannel struct {
 	Name     string `json:"name"`
 	Username string `json:"username,omitempty"`
 	Added    string `json:"added"` // Date added as string
+	Group    string `json:"group,omitempty"`
 }
 
 // ExcelProcessedVideo represents a processed video record in Excel
@@ -31,18 +41,56 @@ type ExcelProcessedVideo struct {
 
 // ExcelSummary represents a summary record in Excel
 type ExcelSummary struct {
-	ID           string `json:"id"`
-	VideoID      string `json:"video_id"`
-	VideoTitle   string `json:"video_title"`
-	ChannelName  string `json:"channel_name"`
-	Summary      string `json:"summary"`
-	CreatedAt    string `json:"created_at"` // Date as string
-	Status       string `json:"status"`     // New, Processed
-	VideoURL     string `json:"video_url"`
-	PublishedAt  string `json:"published_at"`
-	ThumbnailURL string `json:"thumbnail_url"`
-	Duration     string `json:"duration"`
-	ViewCount    string `json:"view_count"` // String for Excel compatibility
+	ID             string `json:"id"`
+	VideoID        string `json:"video_id"`
+	VideoTitle     string `json:"video_title"`
+	ChannelName    string `json:"channel_name"`
+	Summary        string `json:"summary"`
+	CreatedAt      string `json:"created_at"` // Date as string
+	Status         string `json:"status"`     // New, Processed, Skipped, Removed
+	VideoURL       string `json:"video_url"`
+	PublishedAt    string `json:"published_at"`
+	ThumbnailURL   string `json:"thumbnail_url"`
+	Duration       string `json:"duration"`
+	ViewCount      string `json:"view_count"` // String for Excel compatibility
+	WordCount      string `json:"word_count"`
+	ReadingMinutes string `json:"reading_minutes"`
+	Provider       string `json:"provider"`
+	Source         string `json:"source"`
+	Model          string `json:"model"`
+	TranscriptLang string `json:"transcript_lang"`
+	Group          string `json:"group"`
+	Quotes         string `json:"quotes"` // One quote per line
+	ChannelID      string `json:"channel_id"`
+	Audience       string `json:"audience"`
+}
+
+// fields maps each Summaries sheet header to the field stored under it
+func (es *ExcelSummary) fields() map[string]*string {
+	return map[string]*string{
+		"ID":             &es.ID,
+		"VideoID":        &es.VideoID,
+		"VideoTitle":     &es.VideoTitle,
+		"ChannelName":    &es.ChannelName,
+		"Summary":        &es.Summary,
+		"CreatedAt":      &es.CreatedAt,
+		"Status":         &es.Status,
+		"VideoURL":       &es.VideoURL,
+		"PublishedAt":    &es.PublishedAt,
+		"ThumbnailURL":   &es.ThumbnailURL,
+		"Duration":       &es.Duration,
+		"ViewCount":      &es.ViewCount,
+		"WordCount":      &es.WordCount,
+		"ReadingMinutes": &es.ReadingMinutes,
+		"Provider":       &es.Provider,
+		"Source":         &es.Source,
+		"Model":          &es.Model,
+		"TranscriptLang": &es.TranscriptLang,
+		"Group":          &es.Group,
+		"Quotes":         &es.Quotes,
+		"ChannelID":      &es.ChannelID,
+		"Audience":       &es.Audience,
+	}
 }
 
 // ToChannel converts ExcelChannel to types.Channel
@@ -51,6 +99,7 @@ func (ec *ExcelChannel) ToChannel() types.Channel {
 		ID:       ec.ID,
 		Name:     ec.Name,
 		Username: ec.Username,
+		Group:    ec.Group,
 	}
 }
 
@@ -61,6 +110,7 @@ func FromChannel(c types.Channel) ExcelChannel {
 		Name:     c.Name,
 		Username: c.Username,
 		Added:    time.Now().Format("2006-01-02"),
+		Group:    c.Group,
 	}
 }
 
@@ -91,43 +141,77 @@ func (es *ExcelSummary) ToSummary() (types.Summary, error) {
 		}
 	}
 
+	wordCount, _ := strconv.Atoi(es.WordCount)
+	readingMinutes, _ := strconv.Atoi(es.ReadingMinutes)
+
 	return types.Summary{
-		ID:           es.ID,
-		VideoID:      es.VideoID,
-		VideoTitle:   es.VideoTitle,
-		ChannelName:  es.ChannelName,
-		Summary:      es.Summary,
-		CreatedAt:    createdAt,
-		Status:       es.Status,
-		VideoURL:     es.VideoURL,
-		PublishedAt:  publishedAt,
-		ThumbnailURL: es.ThumbnailURL,
-		Duration:     es.Duration,
-		ViewCount:    viewCount,
+		ID:             es.ID,
+		VideoID:        es.VideoID,
+		VideoTitle:     es.VideoTitle,
+		ChannelName:    es.ChannelName,
+		Summary:        es.Summary,
+		CreatedAt:      createdAt,
+		Status:         es.Status,
+		VideoURL:       es.VideoURL,
+		PublishedAt:    publishedAt,
+		ThumbnailURL:   es.ThumbnailURL,
+		Duration:       es.Duration,
+		ViewCount:      viewCount,
+		WordCount:      wordCount,
+		ReadingMinutes: readingMinutes,
+		Provider:       es.Provider,
+		Model:          es.Model,
+		Source:         es.Source,
+		TranscriptLang: es.TranscriptLang,
+		Group:          es.Group,
+		Quotes:         splitQuotes(es.Quotes),
+		ChannelID:      es.ChannelID,
+		Audience:       es.Audience,
 	}, nil
 }
 
 // FromSummary converts types.Summary to ExcelSummary
 func FromSummary(s types.Summary) ExcelSummary {
 	return ExcelSummary{
-		ID:           s.ID,
-		VideoID:      s.VideoID,
-		VideoTitle:   s.VideoTitle,
-		ChannelName:  s.ChannelName,
-		Summary:      s.Summary,
-		CreatedAt:    s.CreatedAt.Format("2006-01-02 15:04:05"),
-		Status:       s.Status,
-		VideoURL:     s.VideoURL,
-		PublishedAt:  s.PublishedAt.Format("2006-01-02 15:04:05"),
-		ThumbnailURL: s.ThumbnailURL,
-		Duration:     s.Duration,
-		ViewCount:    strconv.FormatInt(s.ViewCount, 10),
+		ID:             s.ID,
+		VideoID:        s.VideoID,
+		VideoTitle:     s.VideoTitle,
+		ChannelName:    s.ChannelName,
+		Summary:        s.Summary,
+		CreatedAt:      s.CreatedAt.Format("2006-01-02 15:04:05"),
+		Status:         s.Status,
+		VideoURL:       s.VideoURL,
+		PublishedAt:    s.PublishedAt.Format("2006-01-02 15:04:05"),
+		ThumbnailURL:   s.ThumbnailURL,
+		Duration:       s.Duration,
+		ViewCount:      strconv.FormatInt(s.ViewCount, 10),
+		WordCount:      strconv.Itoa(s.WordCount),
+		ReadingMinutes: strconv.Itoa(s.ReadingMinutes),
+		Provider:       s.Provider,
+		Source:         s.Source,
+		Model:          s.Model,
+		TranscriptLang: s.TranscriptLang,
+		Group:          s.Group,
+		Quotes:         strings.Join(s.Quotes, "\n"),
+		ChannelID:      s.ChannelID,
+		Audience:       s.Audience,
+	}
+}
+
+// splitQuotes reads the one-quote-per-line Quotes cell
+func splitQuotes(cell string) []string {
+	var quotes []string
+	for _, line := range strings.Split(cell, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			quotes = append(quotes, line)
+		}
 	}
+	return quotes
 }
 
 // ChannelHeaders returns the Excel column headers for channels
 func ChannelHeaders() []string {
-	return []string{"ID", "Name", "Username", "Added"}
+	return []string{"ID", "Name", "Username", "Added", "Group"}
 }
 
 // ProcessedVideoHeaders returns the Excel column headers for processed videos
@@ -135,7 +219,27 @@ func ProcessedVideoHeaders() []string {
 	return []string{"VideoID", "ChannelID", "Title", "ProcessedAt"}
 }
 
+// ChannelHistoryHeaders returns the Excel column headers for channel history
+func ChannelHistoryHeaders() []string {
+	return []string{"ChannelID", "FirstProcessedAt"}
+}
+
+// ChannelActivityHeaders returns the Excel column headers for channel activity
+func ChannelActivityHeaders() []string {
+	return []string{"ChannelID", "LastUploadAt", "LastCheckedAt"}
+}
+
+// FailedVideoHeaders returns the Excel column headers for failed videos
+func FailedVideoHeaders() []string {
+	return []string{"VideoID", "ChannelID", "Channel", "Title", "Error", "FailedAt", "Attempts"}
+}
+
+// StateHeaders returns the Excel column headers for the key/value run state
+func StateHeaders() []string {
+	return []string{"Key", "Value"}
+}
+
 // SummaryHeaders returns the Excel column headers for summaries
 func SummaryHeaders() []string {
-	return []string{"ID", "VideoID", "VideoTitle", "ChannelName", "Summary", "CreatedAt", "Status", "VideoURL", "PublishedAt", "ThumbnailURL", "Duration", "ViewCount"}
+	return []string{"ID", "VideoID", "VideoTitle", "ChannelName", "Summary", "CreatedAt", "Status", "VideoURL", "PublishedAt", "ThumbnailURL", "Duration", "ViewCount", "WordCount", "ReadingMinutes", "Provider", "Source", "Model", "TranscriptLang", "Group", "Quotes", "ChannelID", "Audience"}
 }
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// RepairResult describes what Repair recovered for each sheet
+type RepairResult struct {
+	// CorruptCopy is where the unreadable original was moved (empty if there was none)
+	CorruptCopy string
+	Sheets      []SheetRecovery
+}
+
+// SheetRecovery records how many data rows of a sheet were recovered and from which file
+type SheetRecovery struct {
+	Sheet  string
+	Rows   int
+	Source string // empty when the sheet could not be read from any file
+}
+
+// repairSheets lists every sheet Repair rebuilds, with its headers
+var repairSheets = []struct {
+	name    string
+	headers []string
+}{
+	{ChannelsSheet, ChannelHeaders()},
+	{ProcessedVideosSheet, ProcessedVideoHeaders()},
+	{SummariesSheet, SummaryHeaders()},
+	{ChannelHistorySheet, ChannelHistoryHeaders()},
+	{ChannelActivitySheet, ChannelActivityHeaders()},
+	{StateSheet, StateHeaders()},
+	{FailedVideosSheet, FailedVideoHeaders()},
+}
+
+// Repair rebuilds a clean workbook from whatever rows can still be read.
+// Each sheet is read from the data file if possible, otherwise from the newest
+// backup that has it; rows are read one at a time so a damaged sheet still yields
+// the rows before the damage. Recovered sheets keep their own column order, with any
+// missing headers appended. The original file is kept as <name>.corrupt-<timestamp>.
+func (es *ExcelStorage) Repair() (RepairResult, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	var result RepairResult
+
+	sources := []string{es.filePath}
+	backups, err := es.ListBackups()
+	if err != nil {
+		return result, err
+	}
+	for i := len(backups) - 1; i >= 0; i-- {
+		sources = append(sources, backups[i])
+	}
+
+	file := excelize.NewFile()
+	defer file.Close()
+	defaultSheets := file.GetSheetList()
+
+	for _, sheet := range repairSheets {
+		if _, err := file.NewSheet(sheet.name); err != nil {
+			return result, fmt.Errorf("failed to create sheet %s: %w", sheet.name, err)
+		}
+
+		recovery := SheetRecovery{Sheet: sheet.name}
+		for _, source := range sources {
+			header, rows, err := readSheetRows(source, sheet.name)
+			if err != nil && len(rows) == 0 {
+				es.logger.Warn("Could not read sheet", "sheet", sheet.name, "file", source, "error", err)
+				continue
+			}
+			if err != nil {
+				es.logger.Warn("Sheet is damaged, keeping the rows read before the damage", "sheet", sheet.name, "file", source, "rows", len(rows), "error", err)
+			}
+
+			if err := writeRecoveredRows(file, sheet.name, header, rows); err != nil {
+				return result, err
+			}
+			recovery.Rows = len(rows)
+			recovery.Source = source
+			break
+		}
+		result.Sheets = append(result.Sheets, recovery)
+
+		if err := es.ensureSheet(file, sheet.name, sheet.headers); err != nil {
+			return result, err
+		}
+	}
+
+	for _, sheetName := range defaultSheets {
+		if err := file.DeleteSheet(sheetName); err != nil {
+			return result, fmt.Errorf("failed to delete default sheet %s: %w", sheetName, err)
+		}
+	}
+	if index, err := file.GetSheetIndex(ChannelsSheet); err == nil && index >= 0 {
+		file.SetActiveSheet(index)
+	}
+
+	// Keep the damaged original in case more can be salvaged by hand
+	if _, err := os.Stat(es.filePath); err == nil {
+		result.CorruptCopy = fmt.Sprintf("%s.corrupt-%s%s", es.backupPrefix(), time.Now().Format(backupTimestampFormat), filepath.Ext(es.filePath))
+		if err := copyFile(es.filePath, result.CorruptCopy); err != nil {
+			return result, fmt.Errorf("failed to keep a copy of the damaged file: %w", err)
+		}
+	}
+
+	if err := es.saveWithRetry(file); err != nil {
+		return result, err
+	}
+
+	es.processedMu.Lock()
+	es.processedIDs = nil
+	es.processedMu.Unlock()
+
+	es.logger.Info("Repaired Excel file", "path", es.filePath)
+	return result, nil
+}
+
+// readSheetRows returns the header row and the data rows of a sheet, skipping
+// blank rows. On a read error it returns the rows read so far along with the error.
+func readSheetRows(path, sheet string) ([]string, [][]string, error) {
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	rowIterator, err := file.Rows(sheet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+	}
+	defer rowIterator.Close()
+
+	var header []string
+	var rows [][]string
+	for first := true; rowIterator.Next(); first = false {
+		columns, err := rowIterator.Columns()
+		if err != nil {
+			return header, rows, fmt.Errorf("failed to read row %d of sheet %s: %w", len(rows)+2, sheet, err)
+		}
+		if first {
+			header = columns
+			continue
+		}
+		if len(columns) == 0 || columns[0] == "" {
+			continue
+		}
+		rows = append(rows, columns)
+	}
+	if err := rowIterator.Error(); err != nil {
+		return header, rows, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+	}
+
+	return header, rows, nil
+}
+
+// writeRecoveredRows writes a recovered header row and the data rows below it
+func writeRecoveredRows(file *excelize.File, sheet string, header []string, rows [][]string) error {
+	if len(header) > 0 {
+		values := make([]interface{}, len(header))
+		for i, value := range header {
+			values[i] = value
+		}
+		if err := file.SetSheetRow(sheet, "A1", &values); err != nil {
+			return fmt.Errorf("failed to write recovered header of sheet %s: %w", sheet, err)
+		}
+	}
+
+	for i, row := range rows {
+		values := make([]interface{}, len(row))
+		for j, value := range row {
+			values[j] = value
+		}
+		cell := fmt.Sprintf("A%d", i+2)
+		if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+			return fmt.Errorf("failed to write recovered row %s of sheet %s: %w", cell, sheet, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// Rough token estimates for -stats: English text averages about 0.75 words and
+// 4 characters per token
+const (
+	tokensPerWord    = 4.0 / 3.0
+	charsPerToken    = 4
+	promptTokensBase = 100 // prompt text around the transcript
+)
+
+// aggregateSummaries totals summaries for GetSummaryAggregates. Skipped summaries
+// have no text, so only summaries with text count towards lengths, and cached
+// ones cost no AI call, so they're left out of the token estimate.
+func aggregateSummaries(summaries []types.Summary, now time.Time) types.SummaryAggregates {
+	aggregates := types.SummaryAggregates{
+		ByStatus:      make(map[string]int),
+		ByChannel:     make(map[string]int),
+		TokensByModel: make(map[string]types.TokenUsage),
+	}
+	for _, summary := range summaries {
+		aggregates.Total++
+		aggregates.ByStatus[summary.Status]++
+		aggregates.ByChannel[summary.ChannelName]++
+
+		age := now.Sub(summary.CreatedAt)
+		if age < 7*24*time.Hour {
+			aggregates.Last7Days++
+		}
+		if age < 30*24*time.Hour {
+			aggregates.Last30Days++
+		}
+
+		if summary.Summary == "" {
+			continue
+		}
+		aggregates.Summarized++
+		aggregates.SummaryChars += len(summary.Summary)
+
+		if summary.Provider == "cached" {
+			continue
+		}
+		input := promptTokensBase + int(float64(summary.WordCount)*tokensPerWord)
+		output := len(summary.Summary) / charsPerToken
+		aggregates.InputTokens += input
+		aggregates.OutputTokens += output
+
+		model := summary.Model
+		if model == "" {
+			model = summary.Provider
+		}
+		usage := aggregates.TokensByModel[model]
+		usage.Input += input
+		usage.Output += output
+		aggregates.TokensByModel[model] = usage
+	}
+	return aggregates
+}
+
+// GetSummaryAggregates totals every stored summary as of now
+func (es *ExcelStorage) GetSummaryAggregates(ctx context.Context, now time.Time) (types.SummaryAggregates, error) {
+	summaries, err := es.loadSummaries()
+	if err != nil {
+		return types.SummaryAggregates{}, err
+	}
+	return aggregateSummaries(summaries, now), nil
+}
+
+// GetSummaryAggregates totals every stored summary as of now
+func (ms *MemoryStorage) GetSummaryAggregates(ctx context.Context, now time.Time) (types.SummaryAggregates, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return aggregateSummaries(ms.summaries, now), nil
+}
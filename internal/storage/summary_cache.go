@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// FileSummaryCache implements the types.SummaryCache interface on disk.
+// Entries are keyed by video ID plus a hash of the prompt, so changing the
+// prompt naturally misses the cache and no explicit invalidation is needed.
+type FileSummaryCache struct {
+	dir    string
+	logger types.Logger
+}
+
+// NewFileSummaryCache creates a summary cache rooted at dir
+func NewFileSummaryCache(dir string, logger types.Logger) *FileSummaryCache {
+	return &FileSummaryCache{
+		dir:    dir,
+		logger: logger,
+	}
+}
+
+// Get returns the cached summary for a video and prompt, if any
+func (fc *FileSummaryCache) Get(videoID, prompt string) (string, bool) {
+	data, err := os.ReadFile(fc.path(videoID, prompt))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Put stores a summary for a video and prompt
+func (fc *FileSummaryCache) Put(videoID, prompt, summary string) error {
+	if err := os.MkdirAll(fc.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create summary cache directory: %w", err)
+	}
+
+	path := fc.path(videoID, prompt)
+
+	// Write to a temp file first so a crash never leaves a truncated summary
+	tmp, err := os.CreateTemp(fc.dir, "summary.*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create summary cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(summary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write summary cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write summary cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store cached summary: %w", err)
+	}
+
+	fc.logger.Debug("Cached summary", "videoID", videoID, "path", path)
+	return nil
+}
+
+// path hashes the video ID and prompt together, which also keeps crafted IDs out of the path
+func (fc *FileSummaryCache) path(videoID, prompt string) string {
+	sum := sha256.Sum256([]byte(videoID + "\x00" + prompt))
+	return filepath.Join(fc.dir, hex.EncodeToString(sum[:])+".txt")
+}
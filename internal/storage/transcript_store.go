@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"youtube-summarizer/pkg/types"
+)
+
+// videoIDPattern matches YouTube video IDs, which keeps them safe to use as file names
+var videoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// FileTranscriptStore implements the types.TranscriptStore interface on disk,
+// saving each full transcript as <dir>/<videoID>.txt
+type FileTranscriptStore struct {
+	dir    string
+	logger types.Logger
+}
+
+// NewFileTranscriptStore creates a transcript store rooted at dir
+func NewFileTranscriptStore(dir string, logger types.Logger) *FileTranscriptStore {
+	return &FileTranscriptStore{
+		dir:    dir,
+		logger: logger,
+	}
+}
+
+// Get returns the saved transcript for a video, if any
+func (ts *FileTranscriptStore) Get(videoID string) (string, bool) {
+	if !videoIDPattern.MatchString(videoID) {
+		return "", false
+	}
+	data, err := os.ReadFile(ts.path(videoID))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Put saves a video's transcript, replacing any earlier copy
+func (ts *FileTranscriptStore) Put(videoID, transcript string) error {
+	if !videoIDPattern.MatchString(videoID) {
+		return fmt.Errorf("invalid video ID for transcript file: %q", videoID)
+	}
+	if err := os.MkdirAll(ts.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create transcript directory: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated transcript
+	tmp, err := os.CreateTemp(ts.dir, "transcript.*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create transcript file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(transcript); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write transcript file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write transcript file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), ts.path(videoID)); err != nil {
+		return fmt.Errorf("failed to store transcript: %w", err)
+	}
+
+	ts.logger.Debug("Saved transcript", "videoID", videoID, "path", ts.path(videoID))
+	return nil
+}
+
+// path returns the transcript file for a video
+func (ts *FileTranscriptStore) path(videoID string) string {
+	return filepath.Join(ts.dir, videoID+".txt")
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// iso8601DurationPattern matches YouTube-style durations such as PT1H2M3S, PT45S, or P1DT2H
+var iso8601DurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseISO8601Duration parses an ISO-8601 duration (e.g. "PT1H2M3S") into a time.Duration.
+// Missing components count as zero, so "PT1H" and "PT45S" are valid.
+func ParseISO8601Duration(s string) (time.Duration, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	matches := iso8601DurationPattern.FindStringSubmatch(s)
+	if matches == nil || s == "P" || s == "PT" {
+		return 0, fmt.Errorf("invalid ISO-8601 duration: %q", s)
+	}
+
+	var total time.Duration
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	for i, unit := range units {
+		value := matches[i+1]
+		if value == "" {
+			continue
+		}
+		var amount float64
+		if _, err := fmt.Sscanf(value, "%g", &amount); err != nil {
+			return 0, fmt.Errorf("invalid ISO-8601 duration component %q: %w", value, err)
+		}
+		total += time.Duration(amount * float64(unit))
+	}
+
+	return total, nil
+}
+
+// FormatDuration formats a duration as "1:02:03", or "2:03" when under an hour
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d%time.Hour) / int(time.Minute)
+	seconds := int(d%time.Minute) / int(time.Second)
+
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
+// HumanizeDuration converts an ISO-8601 duration to "1:02:03" form.
+// Values that aren't ISO-8601 (already formatted, or empty) are returned unchanged,
+// and zero durations (e.g. live streams reporting "P0D") become empty.
+func HumanizeDuration(s string) string {
+	d, err := ParseISO8601Duration(s)
+	if err != nil {
+		return s
+	}
+	if d == 0 {
+		return ""
+	}
+	return FormatDuration(d)
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "PT1H2M3S", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{in: "PT45S", want: 45 * time.Second},
+		{in: "PT1H", want: time.Hour},
+		{in: "PT10M", want: 10 * time.Minute},
+		{in: "PT1H30S", want: time.Hour + 30*time.Second},
+		{in: "P1DT2H", want: 26 * time.Hour},
+		{in: "P1D", want: 24 * time.Hour},
+		{in: "P0D", want: 0},
+		{in: "PT1.5S", want: 1500 * time.Millisecond},
+		{in: " pt5m ", want: 5 * time.Minute},
+		{in: "", wantErr: true},
+		{in: "P", wantErr: true},
+		{in: "PT", wantErr: true},
+		{in: "1H2M", wantErr: true},
+		{in: "PT2M1H", wantErr: true},
+		{in: "10:30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseISO8601Duration(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseISO8601Duration(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseISO8601Duration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package types
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by the API clients so callers can react
+// with errors.Is instead of matching error strings
+var (
+	// ErrAuth means the API key was missing, invalid or lacks permission
+	ErrAuth = errors.New("authentication failed")
+	// ErrQuotaExceeded means the daily or account quota is used up
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrRateLimited means the API asked us to slow down; retrying later may succeed
+	ErrRateLimited = errors.New("rate limited")
+	// ErrTimeout means the request didn't complete within the client timeout
+	ErrTimeout = errors.New("request timed out")
+	// ErrServerError means the API failed on its side (5xx); retrying later may succeed
+	ErrServerError = errors.New("server error")
+	// ErrVideoNotFound means the video was deleted or made private
+	ErrVideoNotFound = errors.New("video not found")
+	// ErrTranscriptUnavailable means the video has no transcript to fetch
+	ErrTranscriptUnavailable = errors.New("transcript unavailable")
+)
+
+// IsTransient reports whether an error is worth retrying later: rate limits,
+// timeouts and server errors. Auth, quota and not-found errors are permanent.
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrTimeout) || errors.Is(err, ErrServerError)
+}
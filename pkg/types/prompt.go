@@ -0,0 +1,7 @@
+package types
+
+// DefaultPromptTemplate is the built-in prompt used when no template is given; it is
+// also the default ai.summary_prompt
+const DefaultPromptTemplate = `Video Title: "{title}". Summarize the key takeaways from the following video transcript into a concise paragraph. Focus on the main points and actionable advice:
+
+{transcript}`
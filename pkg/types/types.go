@@ -2,6 +2,8 @@ package types
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 )
 
@@ -10,6 +12,8 @@ type Channel struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	Username string `json:"username,omitempty"`
+	// Group labels the channel (e.g. Tech, News) for email.group_by and email.group_recipients
+	Group string `json:"group,omitempty"`
 }
 
 // Video represents a YouTube video
@@ -20,9 +24,13 @@ type Video struct {
 	ChannelID   string    `json:"channel_id"`
 	ChannelName string    `json:"channel_name"`
 	PublishedAt time.Time `json:"published_at"`
-	Duration    string    `json:"duration"`
-	ViewCount   int64     `json:"view_count"`
-	URL         string    `json:"url"`
+	// Duration is ISO-8601 as returned by the API (e.g. PT12M34S); summaries store
+	// it humanized (12:34)
+	Duration  string `json:"duration"`
+	ViewCount int64  `json:"view_count"`
+	URL       string `json:"url"`
+	// Group is the group of the channel the video was fetched from (not from YouTube)
+	Group string `json:"group,omitempty"`
 }
 
 // Summary represents a video summary
@@ -33,18 +41,125 @@ type Summary struct {
 	ChannelName  string    `json:"channel_name"`
 	Summary      string    `json:"summary"`
 	CreatedAt    time.Time `json:"created_at"`
-	Status       string    `json:"status"` // New, Processed
+	Status       string    `json:"status"` // New, Processed, Skipped, Removed
 	VideoURL     string    `json:"video_url"`
 	PublishedAt  time.Time `json:"published_at"`
 	ThumbnailURL string    `json:"thumbnail_url"`
 	Duration     string    `json:"duration"`
 	ViewCount    int64     `json:"view_count"`
+	// WordCount is the approximate length of the video's transcript (0 if unknown)
+	WordCount int `json:"word_count"`
+	// ReadingMinutes is the estimated time to read the summary
+	ReadingMinutes int `json:"reading_minutes"`
+	// Provider is the AI provider that wrote the summary ("cached" when reused)
+	Provider string `json:"provider"`
+	// Model is the AI model that wrote the summary (empty for cached or older summaries)
+	Model string `json:"model"`
+	// Source is the text the summary was built from: transcript, alt_transcript or description
+	Source string `json:"source"`
+	// TranscriptLang is the language code the transcript came in (empty if unknown)
+	TranscriptLang string `json:"transcript_lang"`
+	// Group is the channel's group when the summary was made (empty if ungrouped)
+	Group string `json:"group"`
+	// Quotes are verbatim quotes from the transcript (ai.extract_quotes; may be empty)
+	Quotes []string `json:"quotes"`
+	// ChannelID is the video's channel (empty for summaries made before it was recorded)
+	ChannelID string `json:"channel_id"`
+	// Audience is the ai.audience the summary was written for (empty for older summaries)
+	Audience string `json:"audience"`
+}
+
+// ChannelActivity records when a channel last uploaded and when its videos were last fetched
+type ChannelActivity struct {
+	LastUploadAt  time.Time
+	LastCheckedAt time.Time
+}
+
+// SummaryAggregates are totals over every stored summary, shown by -stats
+type SummaryAggregates struct {
+	Total     int
+	ByStatus  map[string]int
+	ByChannel map[string]int
+	// Summarized counts summaries with text (not skipped); SummaryChars is their total length
+	Summarized   int
+	SummaryChars int
+	// Last7Days and Last30Days count summaries created within that many days
+	Last7Days  int
+	Last30Days int
+	// InputTokens and OutputTokens estimate the AI tokens used, from transcript word
+	// counts and summary lengths (cached summaries cost none)
+	InputTokens  int
+	OutputTokens int
+	// TokensByModel splits the token estimate by the model that wrote each summary,
+	// or its provider when no model was recorded ("" for older summaries)
+	TokensByModel map[string]TokenUsage
+}
+
+// TokenUsage is an estimated number of AI input and output tokens
+type TokenUsage struct {
+	Input  int
+	Output int
+}
+
+// VideoFailure records a video that failed every processing attempt in a run
+type VideoFailure struct {
+	VideoID   string
+	ChannelID string
+	Channel   string
+	Title     string
+	Error     string
+	FailedAt  time.Time
+	// Attempts counts the runs in which the video failed
+	Attempts int
+}
+
+// Summary sources, from most to least reliable
+const (
+	SourceTranscript    = "transcript"
+	SourceAltTranscript = "alt_transcript"
+	SourceDescription   = "description"
+)
+
+// readingWordsPerMinute is the reading speed used for ReadingMinutes
+const readingWordsPerMinute = 200
+
+// EstimateReadingMinutes returns the minutes needed to read text at ~200 wpm, rounded up
+func EstimateReadingMinutes(text string) int {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	return (words + readingWordsPerMinute - 1) / readingWordsPerMinute
+}
+
+// SummaryFilter selects summaries for listing; zero-valued fields match everything
+type SummaryFilter struct {
+	Status  string    // Exact status, e.g. New or Processed
+	Channel string    // Case-insensitive substring of the channel name
+	Since   time.Time // Only summaries created at or after this time
+}
+
+// Matches reports whether the summary satisfies every set field of the filter
+func (f SummaryFilter) Matches(s Summary) bool {
+	if f.Status != "" && !strings.EqualFold(s.Status, f.Status) {
+		return false
+	}
+	if f.Channel != "" && !strings.Contains(strings.ToLower(s.ChannelName), strings.ToLower(f.Channel)) {
+		return false
+	}
+	if !f.Since.IsZero() && s.CreatedAt.Before(f.Since) {
+		return false
+	}
+	return true
 }
 
 // TranscriptData contains transcript and thumbnail information
 type TranscriptData struct {
 	Transcript   string
 	ThumbnailURL string
+	SegmentCount int    // Caption segments combined into Transcript
+	Source       string // SourceTranscript or SourceAltTranscript
+	Language     string // Language code of the transcript, e.g. "en" (empty if unknown)
 }
 
 // Config represents the application configuration
@@ -54,32 +169,227 @@ type Config struct {
 	Processing ProcessingConfig `yaml:"processing"`
 	Email      EmailConfig      `yaml:"email"`
 	AI         AIConfig         `yaml:"ai"`
+	Timeouts   TimeoutsConfig   `yaml:"timeouts"`
+	Storage    StorageConfig    `yaml:"storage"`
+	Transcript TranscriptConfig `yaml:"transcript"`
+	Notion     NotionConfig     `yaml:"notion"`
+	HTTP       HTTPConfig       `yaml:"http"`
 }
 
 type AppConfig struct {
 	// Removed scheduling - app now runs on-demand
 	// MaxVideosOnFirstRun limits videos processed when running for the first time
 	MaxVideosOnFirstRun int `yaml:"max_videos_on_first_run"`
+	// FirstRunPerChannel applies MaxVideosOnFirstRun to each channel the first time it is
+	// processed, rather than only to the first run of the app
+	FirstRunPerChannel bool `yaml:"first_run_per_channel"`
 }
 
 type YouTubeConfig struct {
 	MaxVideosPerChannel int `yaml:"max_videos_per_channel"`
+	// Channels are monitored in addition to the Channels sheet; entries may be comma-separated
+	Channels []string `yaml:"channels"`
+	// DormantAfter marks channels without an upload for this long as dormant (0 disables)
+	DormantAfter time.Duration `yaml:"dormant_after"`
+	// DormantRecheckInterval is how often dormant channels are fetched
+	DormantRecheckInterval time.Duration `yaml:"dormant_recheck_interval"`
 }
 
 type ProcessingConfig struct {
-	MaxConcurrentVideos int           `yaml:"max_concurrent_videos"`
-	TranscriptTimeout   time.Duration `yaml:"transcript_timeout"`
+	MaxConcurrentVideos      int           `yaml:"max_concurrent_videos"`
+	MaxConcurrentTranscripts int           `yaml:"max_concurrent_transcripts"`
+	TranscriptTimeout        time.Duration `yaml:"transcript_timeout"`
+	// ShareTranscriptFetches makes concurrent fetches of the same video share one API call
+	ShareTranscriptFetches bool `yaml:"share_transcript_fetches"`
+	// AbortAfterFailures aborts the run after this many consecutive AI failures (0 disables)
+	AbortAfterFailures int `yaml:"abort_after_failures"`
+	// Order is "newest" or "oldest": which of a channel's videos are processed first,
+	// and so which are kept when the first-run limit is hit
+	Order string `yaml:"order"`
+	// VideoRetries retries a video's whole pipeline this many times after a transient failure
+	VideoRetries    int           `yaml:"video_retries"`
+	VideoRetryDelay time.Duration `yaml:"video_retry_delay"`
+	// RunTimeout bounds a whole processing run, including the digest (0 = no limit)
+	RunTimeout time.Duration `yaml:"run_timeout"`
+	// MinDuration and MaxDuration limit summarized videos to a length band (0 = no bound)
+	MinDuration time.Duration `yaml:"min_duration"`
+	MaxDuration time.Duration `yaml:"max_duration"`
 }
 
 type EmailConfig struct {
-	SMTPHost        string `yaml:"smtp_host"`
-	SMTPPort        int    `yaml:"smtp_port"`
+	SMTPHost string `yaml:"smtp_host"`
+	SMTPPort int    `yaml:"smtp_port"`
+	// Auth is "login" (EMAIL_USERNAME/EMAIL_PASSWORD) or "none" for an unauthenticated relay
+	Auth            string `yaml:"auth"`
 	SubjectTemplate string `yaml:"subject_template"`
+	// DigestTitle is the heading of the digest email
+	DigestTitle string `yaml:"digest_title"`
+	// HeaderImageURL is an optional logo or banner shown above the title
+	HeaderImageURL string `yaml:"header_image_url"`
+	// DateFormat is the Go time layout for {date} in the subject and the digest header
+	DateFormat string `yaml:"date_format"`
+	// SendWindow restricts digest sending to a local time range such as "07:00-09:00" (empty = always)
+	SendWindow string `yaml:"send_window"`
+	// MinDigestInterval is the minimum time between digests; pending summaries wait for the next run (0 = no gap)
+	MinDigestInterval time.Duration `yaml:"min_digest_interval"`
+	// MinSummaries is the fewest pending summaries worth a digest; fewer wait for a later run
+	MinSummaries int `yaml:"min_summaries"`
+	// ForceSendAfter sends the digest regardless of SendWindow, MinDigestInterval and
+	// MinSummaries once a pending summary is this old (0 = never force)
+	ForceSendAfter time.Duration `yaml:"force_send_after"`
+	// IncludeIntro adds a short AI-written overview of the day's videos (one extra AI call)
+	IncludeIntro bool `yaml:"include_intro"`
+	// RoundupThemes adds an AI-written top-themes overview to the weekly roundup (one extra AI call)
+	RoundupThemes bool `yaml:"roundup_themes"`
+	// ShowThumbnails false sends a compact, text-forward digest without any images
+	ShowThumbnails bool `yaml:"show_thumbnails"`
+	// EmbedThumbnails attaches thumbnails inline instead of linking to YouTube
+	EmbedThumbnails bool `yaml:"embed_thumbnails"`
+	// RenderWorkers is the number of parallel thumbnail downloads when embedding
+	RenderWorkers int `yaml:"render_workers"`
+	// VerifyVideos checks each pending video still exists before sending (one YouTube API call per video)
+	VerifyVideos bool `yaml:"verify_videos"`
+	// MaxPerChannel caps summaries shown per channel in the digest; the rest are listed as links (0 = no cap)
+	MaxPerChannel int `yaml:"max_per_channel"`
+	// RenderMarkdown converts markdown in summaries to sanitized HTML in the email
+	RenderMarkdown bool `yaml:"render_markdown"`
+	// SummaryMaxChars shortens longer summaries in the email to a "read more" link (0 = no limit)
+	SummaryMaxChars int `yaml:"summary_max_chars"`
+	// PlainText adds a plain-text version of the digest for screen readers, text-only
+	// clients and spam filters (on by default)
+	PlainText bool `yaml:"plain_text"`
+	// AttachPDF attaches the digest rendered to PDF by PDFCommand; the email is still sent if that fails
+	AttachPDF  bool   `yaml:"attach_pdf"`
+	PDFCommand string `yaml:"pdf_command"`
+	// Recipients receive the digest; when empty it is sent to the sender's own address
+	Recipients []string `yaml:"recipients"`
+	// CC and BCC are copied on every email, including routed digests
+	CC  []string `yaml:"cc"`
+	BCC []string `yaml:"bcc"`
+	// SendToSelf additionally BCCs the sender when Recipients are set, for archival
+	SendToSelf bool `yaml:"send_to_self"`
+	// TransferEncoding is the Content-Transfer-Encoding of the HTML body: quoted-printable, base64 or 8bit
+	TransferEncoding string `yaml:"transfer_encoding"`
+	// ListUnsubscribe is the https: or mailto: URL sent in the List-Unsubscribe header (empty = no header)
+	ListUnsubscribe string `yaml:"list_unsubscribe"`
+	// GroupBy "group" sections the digest by channel group (empty = one flat list)
+	GroupBy string `yaml:"group_by"`
+	// GroupRecipients sends the named groups as separate digests to their own recipients
+	GroupRecipients map[string][]string `yaml:"group_recipients"`
+	// Routes sends the summaries of a channel group or channel ID as a separate digest
+	// to its own recipients; unrouted summaries go to Recipients
+	Routes map[string][]string `yaml:"routes"`
 }
 
 type AIConfig struct {
-	MaxTranscriptLength int    `yaml:"max_transcript_length"`
-	SummaryPrompt       string `yaml:"summary_prompt"`
+	MaxTranscriptLength int `yaml:"max_transcript_length"`
+	// MinTranscriptLength skips summarization of shorter transcripts (0 disables the guard)
+	MinTranscriptLength int `yaml:"min_transcript_length"`
+	// MaxTokens caps the length of each Claude response
+	MaxTokens int `yaml:"max_tokens"`
+	// Temperature is Claude's sampling temperature, from 0 (most deterministic) to 1
+	Temperature float64 `yaml:"temperature"`
+	// SummaryPrompt is the prompt template with {title} and {transcript} placeholders;
+	// empty uses the built-in prompt
+	SummaryPrompt string `yaml:"summary_prompt"`
+	// Prompts holds category-specific prompt templates (tutorial, news, review) chosen from the video title
+	Prompts map[string]string `yaml:"prompts"`
+	// Questions, when set, replace the summary with answers to each question
+	Questions []string `yaml:"questions"`
+	// Audience tunes the vocabulary and depth of summaries: beginner, general or expert
+	Audience string `yaml:"audience"`
+	// ExtractQuotes asks for 2-3 verbatim quotes per video in a second AI call
+	ExtractQuotes bool `yaml:"extract_quotes"`
+	// LogRequests logs full prompts and raw AI responses at debug level, independently of -dev
+	LogRequests bool `yaml:"log_requests"`
+	// Providers lists the AI providers to try in order (claude, openai)
+	Providers []string `yaml:"providers"`
+	// ThrottleMinRequests and ThrottleMinTokens pause Claude requests until the rate limit
+	// resets once fewer requests or tokens remain (0 disables)
+	ThrottleMinRequests int `yaml:"throttle_min_requests"`
+	ThrottleMinTokens   int `yaml:"throttle_min_tokens"`
+	// RateLimitRetries is how many times a Claude request rejected with 429 is retried
+	// after the wait the API asks for (0 disables)
+	RateLimitRetries int `yaml:"rate_limit_retries"`
+	// StripPhrases are removed from every transcript before summarizing (e.g. a recurring sponsor read)
+	StripPhrases []string `yaml:"strip_phrases"`
+	// ChannelMarkers cut a channel's intro and outro from its transcripts, keyed by channel ID or name
+	ChannelMarkers map[string]TranscriptMarkers `yaml:"channel_markers"`
+	// Examples are sample transcript/summary pairs sent as prior conversation turns (few-shot)
+	Examples []AIExample `yaml:"examples"`
+}
+
+// TranscriptMarkers locate a channel's recurring intro and outro in a transcript
+type TranscriptMarkers struct {
+	// IntroEnd drops the transcript up to and including its first occurrence
+	IntroEnd string `yaml:"intro_end"`
+	// OutroStart drops the transcript from its last occurrence on
+	OutroStart string `yaml:"outro_start"`
+}
+
+// AIExample is one few-shot example: a transcript and the summary it should produce
+type AIExample struct {
+	// Title fills {title} in the prompt for this example (optional)
+	Title  string `yaml:"title"`
+	Input  string `yaml:"input"`
+	Output string `yaml:"output"`
+}
+
+// TimeoutsConfig holds per-client HTTP request timeouts
+type TimeoutsConfig struct {
+	YouTube    time.Duration `yaml:"youtube"`
+	Transcript time.Duration `yaml:"transcript"`
+	AI         time.Duration `yaml:"ai"`
+	Notion     time.Duration `yaml:"notion"`
+}
+
+type StorageConfig struct {
+	// ExcelPath is the data file for this profile when running with -config-dir
+	ExcelPath string `yaml:"excel_path"`
+	// SummaryCacheDir caches AI summaries by video and prompt to avoid paying twice (empty disables the cache)
+	SummaryCacheDir string `yaml:"summary_cache_dir"`
+	// SaveTranscripts writes each fetched transcript to TranscriptDir as <videoID>.txt
+	SaveTranscripts bool   `yaml:"save_transcripts"`
+	TranscriptDir   string `yaml:"transcript_dir"`
+	// ThumbnailDir caches downloaded thumbnails for the HTTP UI (empty disables the cache)
+	ThumbnailDir string `yaml:"thumbnail_dir"`
+	// BackupsToKeep is the number of rotating Excel backups taken before each run or
+	// command that writes to the file (0 disables backups)
+	BackupsToKeep int `yaml:"backups_to_keep"`
+	// SaveRetries is the number of extra save attempts when the Excel file is locked
+	SaveRetries    int           `yaml:"save_retries"`
+	SaveRetryDelay time.Duration `yaml:"save_retry_delay"`
+	// Mode is "history" (keep every summary) or "latest" (keep only the newest LatestCount,
+	// never evicting summaries that are still pending)
+	Mode        string `yaml:"mode"`
+	LatestCount int    `yaml:"latest_count"`
+}
+
+// TranscriptConfig selects the RapidAPI transcript provider
+type TranscriptConfig struct {
+	Host    string `yaml:"host"`     // Sent as the x-rapidapi-host header
+	BaseURL string `yaml:"base_url"` // Provider endpoint, e.g. https://<host>
+	// Languages are the preferred transcript languages, tried in order; when the video
+	// has none of them, its first available language is used. "auto" stops at the
+	// provider's default.
+	Languages []string `yaml:"languages"`
+}
+
+// NotionConfig configures the -export-notion summary export.
+// The integration token is read from NOTION_API_KEY.
+type NotionConfig struct {
+	DatabaseID string `yaml:"database_id"`
+}
+
+// HTTPConfig holds settings shared by all outbound HTTP clients
+type HTTPConfig struct {
+	// Proxy is the proxy URL for API requests; empty uses HTTPS_PROXY/HTTP_PROXY/NO_PROXY
+	Proxy string `yaml:"proxy"`
+	// RetryAttempts is how often a request is tried in total when it gets a 429, 5xx or
+	// network error; the wait starts at RetryBaseDelay and doubles up to RetryMaxDelay
+	RetryAttempts  int           `yaml:"retry_attempts"`
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay"`
+	RetryMaxDelay  time.Duration `yaml:"retry_max_delay"`
 }
 
 // Core interfaces for future UI expansion
@@ -87,29 +397,111 @@ type AIConfig struct {
 // VideoProcessor handles the main business logic
 type VideoProcessor interface {
 	ProcessNewVideos(ctx context.Context) error
+	// ProcessVideo summarizes one video by ID; force re-summarizes an already processed video
+	ProcessVideo(ctx context.Context, videoID string, force bool) (Summary, error)
 	GetProcessedVideos(ctx context.Context) ([]Video, error)
 	UpdateConfig(config Config) error
 }
 
+// ErrDuplicateSummaryID is returned by SaveSummary when a summary with the same ID already exists
+var ErrDuplicateSummaryID = errors.New("summary ID already exists")
+
+// ErrDuplicateChannel is returned by AddChannel when a channel with the same ID already exists
+var ErrDuplicateChannel = errors.New("channel already exists")
+
+// ErrChannelNotFound is returned by RemoveChannel when no channel has the given ID
+var ErrChannelNotFound = errors.New("channel not found")
+
 // Storage handles data persistence
 type Storage interface {
 	GetChannels(ctx context.Context) ([]Channel, error)
+	// AddChannel appends a channel, rejecting IDs that are already listed
+	AddChannel(ctx context.Context, channel Channel) error
+	// RemoveChannel deletes the channel with the given ID
+	RemoveChannel(ctx context.Context, channelID string) error
 	SaveSummary(ctx context.Context, summary Summary) error
 	GetPendingSummaries(ctx context.Context) ([]Summary, error)
+	GetAllSummaries(ctx context.Context, offset, limit int) ([]Summary, int, error)
+	GetSummariesByDateRange(ctx context.Context, start, end time.Time) ([]Summary, error)
+	// GetSummaryChannels returns the unique, sorted channel names that have summaries
+	GetSummaryChannels(ctx context.Context) ([]string, error)
+	// UpdateSummary replaces the stored summary that has the same ID
+	UpdateSummary(ctx context.Context, summary Summary) error
 	MarkSummariesProcessed(ctx context.Context, summaryIDs []string) error
+	UpdateSummaryStatus(ctx context.Context, summaryIDs []string, status string) error
 	IsVideoProcessed(ctx context.Context, videoID string) (bool, error)
-	MarkVideoProcessed(ctx context.Context, videoID string) error
+	MarkVideoProcessed(ctx context.Context, video Video) error
+	// ClearProcessedVideos forgets processed videos for a channel (empty channelID = all)
+	// so they are picked up again on the next run; it returns the number cleared
+	ClearProcessedVideos(ctx context.Context, channelID string) (int, error)
+	// GetChannelsFirstProcessed returns when each channel was first processed, keyed by
+	// channel ID. Unlike processed videos, this history survives ClearProcessedVideos.
+	GetChannelsFirstProcessed(ctx context.Context) (map[string]time.Time, error)
+	MarkChannelFirstProcessed(ctx context.Context, channelID string, at time.Time) error
+	// GetChannelActivity returns each tracked channel's last upload and last check, keyed by channel ID
+	GetChannelActivity(ctx context.Context) (map[string]ChannelActivity, error)
+	SetChannelActivity(ctx context.Context, channelID string, activity ChannelActivity) error
+	// GetLastDigestSent returns when the last digest was sent (zero if never)
+	GetLastDigestSent(ctx context.Context) (time.Time, error)
+	SetLastDigestSent(ctx context.Context, at time.Time) error
+	// GetLastSuccessfulRun returns when the last run that completed without failures started (zero if never)
+	GetLastSuccessfulRun(ctx context.Context) (time.Time, error)
+	SetLastSuccessfulRun(ctx context.Context, at time.Time) error
+	// RecordVideoFailure stores a failed video, replacing an earlier failure of the
+	// same video and counting the attempt
+	RecordVideoFailure(ctx context.Context, failure VideoFailure) error
+	GetVideoFailures(ctx context.Context) ([]VideoFailure, error)
+	// GetSummaryAggregates totals every stored summary, counting recent ones relative to now
+	GetSummaryAggregates(ctx context.Context, now time.Time) (SummaryAggregates, error)
+	// Compact rewrites the stored data without blank rows or gaps
+	Compact(ctx context.Context) error
 }
 
 // AIClient handles AI summarization
 type AIClient interface {
 	Summarize(ctx context.Context, transcript, title string) (string, error)
+	// SummarizeWithPrompt uses a prompt template with {title} and {transcript} placeholders
+	SummarizeWithPrompt(ctx context.Context, promptTemplate, transcript, title string) (string, error)
+}
+
+// NamedAIClient is an AIClient that can take part in the ai.providers fallback chain
+type NamedAIClient interface {
+	AIClient
+	Name() string
+	GetModel() string
+	// SummarizeWithExamples precedes the prompt with few-shot example turns
+	SummarizeWithExamples(ctx context.Context, promptTemplate, transcript, title string, examples []AIExample) (string, error)
+}
+
+// ProviderAIClient is implemented by AI clients that can report which
+// provider produced a summary
+type ProviderAIClient interface {
+	SummarizeWithProvider(ctx context.Context, promptTemplate, transcript, title string, examples []AIExample) (summary, provider string, err error)
+	// ProviderModel returns the model the named provider summarizes with
+	ProviderModel(provider string) string
+}
+
+// APICallCount is the number of requests made to one external API
+type APICallCount struct {
+	API   string `json:"api"`
+	Calls int64  `json:"calls"`
+	// Tokens is the input plus output tokens used, for AI providers
+	Tokens int64 `json:"tokens,omitempty"`
+}
+
+// CallCounter is implemented by clients that count their API requests
+type CallCounter interface {
+	APICalls() []APICallCount
 }
 
 // YouTubeClient handles YouTube API interactions
 type YouTubeClient interface {
-	GetChannelVideos(ctx context.Context, channelID string, maxResults int) ([]Video, error)
+	// GetChannelVideos returns up to maxResults recent videos, newest first; a non-zero
+	// publishedAfter leaves out videos published at or before it
+	GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter time.Time) ([]Video, error)
 	GetVideoDetails(ctx context.Context, videoID string) (*Video, error)
+	// ResolveChannelID turns an @handle or legacy username into a channel ID
+	ResolveChannelID(ctx context.Context, handleOrUsername string) (string, error)
 }
 
 // TranscriptClient handles transcript fetching
@@ -118,6 +510,23 @@ type TranscriptClient interface {
 	GetTranscriptWithThumbnail(ctx context.Context, videoID string) (*TranscriptData, error)
 }
 
+// ThumbnailCache stores video thumbnails locally
+type ThumbnailCache interface {
+	Fetch(ctx context.Context, videoID, thumbnailURL string) (string, error)
+}
+
+// SummaryCache stores AI summaries keyed by video ID and prompt
+type SummaryCache interface {
+	Get(videoID, prompt string) (string, bool)
+	Put(videoID, prompt, summary string) error
+}
+
+// TranscriptStore keeps full transcripts keyed by video ID for later re-analysis
+type TranscriptStore interface {
+	Get(videoID string) (string, bool)
+	Put(videoID, transcript string) error
+}
+
 // EmailService handles email delivery
 type EmailService interface {
 	SendDigest(ctx context.Context, summaries []Summary) error